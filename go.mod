@@ -4,7 +4,6 @@ go 1.21.5
 
 require (
 	github.com/go-chi/chi/v5 v5.0.11
-	github.com/go-chi/jwtauth v1.2.0
 	github.com/go-playground/validator/v10 v10.16.0
 	github.com/go-sql-driver/mysql v1.7.1
 	github.com/google/uuid v1.5.0
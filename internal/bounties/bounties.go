@@ -0,0 +1,280 @@
+package bounties
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// The credits of the priority level are held in escrow as the bounty of the question.
+// When the question is closed, the bounty is paid to the accepted answer, or else to
+// the top-voted answer. If the question has not been answered when the bounty expires,
+// the bounty is refunded to the asker and the priority level goes back to 0.
+//
+// Bounties are locked (GetOpenBountyByQuestionId) before the question row is updated,
+// so concurrent settlements cannot deadlock each other.
+// Escrow, Settle and Refund are meant to be called in the transaction of the change.
+
+const bountyExpiryInterval = 10 * time.Minute
+const bountyExpiryBatchSize = 100
+
+var bountyDuration = 7 * 24 * time.Hour
+
+// UseBounties configures how long the bounties run (BOUNTY_DURATION_DAYS, default 7)
+// and starts the job refunding the expired ones.
+func UseBounties() {
+	days := utils.GetIntEnv("BOUNTY_DURATION_DAYS", 7)
+	if days < 1 {
+		log.Fatalln("BOUNTY_DURATION_DAYS must be at least 1.")
+	}
+	bountyDuration = time.Duration(days) * 24 * time.Hour
+
+	go func() {
+		for range time.Tick(bountyExpiryInterval) {
+			if err := refundExpiredBounties(context.Background()); err != nil {
+				log.Println("Error refunding the expired bounties.", err)
+			}
+		}
+	}()
+}
+
+// Escrow puts the credits, already taken from the asker, in escrow for the question.
+// They are added to the open bounty of the question if there is one.
+func Escrow(ctx context.Context, qtx *database.Queries, questionId int32, amount int32) error {
+	if amount == 0 {
+		return nil
+	}
+
+	bounty, err := qtx.GetOpenBountyByQuestionId(ctx, questionId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from qtx.GetOpenBountyByQuestionId method.", err)
+			return err
+		}
+
+		err = qtx.CreateBounty(ctx, database.CreateBountyParams{
+			QuestionID: questionId,
+			Amount:     amount,
+			ExpiresAt:  time.Now().Add(bountyDuration),
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+		if err != nil {
+			log.Println("Error from qtx.CreateBounty method.", err)
+		}
+		return err
+	}
+
+	err = qtx.AddToBounty(ctx, database.AddToBountyParams{
+		Amount:    amount,
+		UpdatedAt: time.Now(),
+		ID:        bounty.ID,
+	})
+	if err != nil {
+		log.Println("Error from qtx.AddToBounty method.", err)
+	}
+	return err
+}
+
+// Settle pays the open bounty of the question that is being closed to the
+// accepted answer, or else to the top-voted answer of another user.
+// The bounty is refunded if there is no such answer.
+func Settle(
+	ctx context.Context,
+	qtx *database.Queries,
+	r *http.Request,
+	actorId int32,
+	question database.GetQuestionByIdRow,
+) error {
+	bounty, err := qtx.GetOpenBountyByQuestionId(ctx, question.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		log.Println("Error from qtx.GetOpenBountyByQuestionId method.", err)
+		return err
+	}
+
+	var answer database.Answer
+	if question.AcceptedAnswerID.Valid {
+		answer, err = qtx.GetAnswerById(ctx, question.AcceptedAnswerID.Int32)
+	} else {
+		answer, err = qtx.GetTopAnswerByQuestionId(ctx, database.GetTopAnswerByQuestionIdParams{
+			QuestionID: question.ID,
+			UserID:     question.UserID,
+		})
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return pay(ctx, qtx, r, actorId, bounty, utils.BOUNTY_REFUNDED, question.UserID, sql.NullInt32{})
+		}
+		log.Println("Error finding the answer to award the bounty to.", err)
+		return err
+	}
+
+	awardedAnswerId := sql.NullInt32{Int32: answer.ID, Valid: true}
+	return pay(ctx, qtx, r, actorId, bounty, utils.BOUNTY_AWARDED, answer.UserID, awardedAnswerId)
+}
+
+// SettleUserBounties settles the open bounties of the questions of the user
+// like closing the questions would. It is used before the account is deleted,
+// otherwise the answerers could only get the bounties of the Deleted User.
+func SettleUserBounties(ctx context.Context, qtx *database.Queries, r *http.Request, userId int32) error {
+	bounties, err := qtx.GetOpenBountiesByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from qtx.GetOpenBountiesByUserId method.", err)
+		return err
+	}
+
+	for _, bounty := range bounties {
+		question, err := qtx.GetQuestionById(ctx, bounty.QuestionID)
+		if err != nil {
+			log.Println("Error from qtx.GetQuestionById method.", err)
+			return err
+		}
+		if err = Settle(ctx, qtx, r, userId, question); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Refund returns the open bounty of the question to the asker.
+func Refund(
+	ctx context.Context,
+	qtx *database.Queries,
+	r *http.Request,
+	actorId int32,
+	questionId int32,
+	askerId int32,
+) error {
+	bounty, err := qtx.GetOpenBountyByQuestionId(ctx, questionId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		log.Println("Error from qtx.GetOpenBountyByQuestionId method.", err)
+		return err
+	}
+	return pay(ctx, qtx, r, actorId, bounty, utils.BOUNTY_REFUNDED, askerId, sql.NullInt32{})
+}
+
+// pay settles the bounty and gives its credits to the user.
+// Nothing is paid if the bounty has already been settled.
+func pay(
+	ctx context.Context,
+	qtx *database.Queries,
+	r *http.Request,
+	actorId int32,
+	bounty database.Bounty,
+	status string,
+	userId int32,
+	awardedAnswerId sql.NullInt32,
+) error {
+	settled, err := qtx.SettleBounty(ctx, database.SettleBountyParams{
+		Status:          status,
+		AwardedAnswerID: awardedAnswerId,
+		SettledAt:       sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt:       time.Now(),
+		ID:              bounty.ID,
+	})
+	if err != nil {
+		log.Println("Error from qtx.SettleBounty method.", err)
+		return err
+	}
+	if settled == 0 {
+		return nil
+	}
+
+	err = qtx.AddUserCredit(ctx, database.AddUserCreditParams{
+		ID:        userId,
+		Credits:   bounty.Amount,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.AddUserCredit method.", err)
+		return err
+	}
+
+	return utils.RecordCreditsChange(ctx, qtx, r, actorId, userId, bounty.Amount, "bounty_"+status, bounty.QuestionID)
+}
+
+// refundExpiredBounties refunds the bounties that have expired without an answer.
+func refundExpiredBounties(ctx context.Context) error {
+	db := database.GetDB()
+
+	bounties, err := db.GetExpiredBounties(ctx, database.GetExpiredBountiesParams{
+		ExpiresAt: time.Now(),
+		Limit:     bountyExpiryBatchSize,
+	})
+	if err != nil {
+		log.Println("Error from db.GetExpiredBounties method.", err)
+		return err
+	}
+
+	for _, bounty := range bounties {
+		if err = refundExpiredBounty(ctx, bounty); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func refundExpiredBounty(ctx context.Context, expired database.Bounty) error {
+	db := database.GetDB()
+
+	question, err := db.GetQuestionById(ctx, expired.QuestionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		log.Println("Error from db.GetQuestionById method.", err)
+		return err
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		return err
+	}
+	qtx := db.WithTx(tx)
+
+	// The bounty may have been settled in the meantime
+	bounty, err := qtx.GetOpenBountyByQuestionId(ctx, expired.QuestionID)
+	if err != nil || bounty.ID != expired.ID {
+		tx.Rollback()
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error from qtx.GetOpenBountyByQuestionId method.", err)
+			return err
+		}
+		return nil
+	}
+
+	err = pay(ctx, qtx, nil, 0, bounty, utils.BOUNTY_REFUNDED, question.UserID, sql.NullInt32{})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = qtx.ResetQuestionPriority(ctx, database.ResetQuestionPriorityParams{
+		UpdatedAt: time.Now(),
+		ID:        question.ID,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ResetQuestionPriority method.", err)
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+	}
+	return err
+}
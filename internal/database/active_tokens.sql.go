@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"time"
 )
 
 const checkIfTokenIsActive = `-- name: CheckIfTokenIsActive :one
@@ -26,19 +27,178 @@ func (q *Queries) CheckIfTokenIsActive(ctx context.Context, arg CheckIfTokenIsAc
 	return count, err
 }
 
-const setActiveToken = `-- name: SetActiveToken :exec
-INSERT INTO active_tokens (sub, jti)
-VALUES (?, ?)
-ON DUPLICATE KEY UPDATE
-sub = VALUES(sub), jti = VALUES(jti)
+const createActiveToken = `-- name: CreateActiveToken :execlastid
+INSERT INTO active_tokens (sub, jti, device_name, user_agent, ip_address, created_at, last_seen_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 `
 
-type SetActiveTokenParams struct {
+type CreateActiveTokenParams struct {
+	Sub        int32
+	Jti        string
+	DeviceName string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+func (q *Queries) CreateActiveToken(ctx context.Context, arg CreateActiveTokenParams) (int64, error) {
+	result, err := q.exec(ctx, q.createActiveTokenStmt, createActiveToken,
+		arg.Sub,
+		arg.Jti,
+		arg.DeviceName,
+		arg.UserAgent,
+		arg.IpAddress,
+		arg.CreatedAt,
+		arg.LastSeenAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const deleteActiveToken = `-- name: DeleteActiveToken :execrows
+DELETE FROM active_tokens
+WHERE id = ? AND sub = ?
+`
+
+type DeleteActiveTokenParams struct {
+	ID  int32
+	Sub int32
+}
+
+func (q *Queries) DeleteActiveToken(ctx context.Context, arg DeleteActiveTokenParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteActiveTokenStmt, deleteActiveToken, arg.ID, arg.Sub)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteActiveTokenByJti = `-- name: DeleteActiveTokenByJti :exec
+DELETE FROM active_tokens
+WHERE sub = ? AND jti = ?
+`
+
+type DeleteActiveTokenByJtiParams struct {
 	Sub int32
 	Jti string
 }
 
+func (q *Queries) DeleteActiveTokenByJti(ctx context.Context, arg DeleteActiveTokenByJtiParams) error {
+	_, err := q.exec(ctx, q.deleteActiveTokenByJtiStmt, deleteActiveTokenByJti, arg.Sub, arg.Jti)
+	return err
+}
+
+const deleteActiveTokensBySub = `-- name: DeleteActiveTokensBySub :exec
+DELETE FROM active_tokens
+WHERE sub = ?
+`
+
+func (q *Queries) DeleteActiveTokensBySub(ctx context.Context, sub int32) error {
+	_, err := q.exec(ctx, q.deleteActiveTokensBySubStmt, deleteActiveTokensBySub, sub)
+	return err
+}
+
+const deleteOtherActiveTokens = `-- name: DeleteOtherActiveTokens :exec
+DELETE FROM active_tokens
+WHERE sub = ? AND id != ?
+`
+
+type DeleteOtherActiveTokensParams struct {
+	Sub int32
+	ID  int32
+}
+
+func (q *Queries) DeleteOtherActiveTokens(ctx context.Context, arg DeleteOtherActiveTokensParams) error {
+	_, err := q.exec(ctx, q.deleteOtherActiveTokensStmt, deleteOtherActiveTokens, arg.Sub, arg.ID)
+	return err
+}
+
+const getActiveTokenId = `-- name: GetActiveTokenId :one
+SELECT id FROM active_tokens
+WHERE sub = ? AND jti = ?
+`
+
+type GetActiveTokenIdParams struct {
+	Sub int32
+	Jti string
+}
+
+func (q *Queries) GetActiveTokenId(ctx context.Context, arg GetActiveTokenIdParams) (int32, error) {
+	row := q.queryRow(ctx, q.getActiveTokenIdStmt, getActiveTokenId, arg.Sub, arg.Jti)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getActiveTokensBySub = `-- name: GetActiveTokensBySub :many
+SELECT id, jti, device_name, user_agent, ip_address, created_at, last_seen_at FROM active_tokens
+WHERE sub = ?
+ORDER BY last_seen_at DESC
+`
+
+type GetActiveTokensBySubRow struct {
+	ID         int32
+	Jti        string
+	DeviceName string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+func (q *Queries) GetActiveTokensBySub(ctx context.Context, sub int32) ([]GetActiveTokensBySubRow, error) {
+	rows, err := q.query(ctx, q.getActiveTokensBySubStmt, getActiveTokensBySub, sub)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetActiveTokensBySubRow
+	for rows.Next() {
+		var i GetActiveTokensBySubRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Jti,
+			&i.DeviceName,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setActiveToken = `-- name: SetActiveToken :exec
+UPDATE active_tokens
+SET jti = ?, ip_address = ?, last_seen_at = ?
+WHERE id = ?
+`
+
+type SetActiveTokenParams struct {
+	Jti        string
+	IpAddress  string
+	LastSeenAt time.Time
+	ID         int32
+}
+
 func (q *Queries) SetActiveToken(ctx context.Context, arg SetActiveTokenParams) error {
-	_, err := q.exec(ctx, q.setActiveTokenStmt, setActiveToken, arg.Sub, arg.Jti)
+	_, err := q.exec(ctx, q.setActiveTokenStmt, setActiveToken,
+		arg.Jti,
+		arg.IpAddress,
+		arg.LastSeenAt,
+		arg.ID,
+	)
 	return err
 }
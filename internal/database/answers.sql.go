@@ -7,10 +7,37 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
-const createAnswer = `-- name: CreateAnswer :exec
+const checkIfAnswerExists = `-- name: CheckIfAnswerExists :one
+SELECT COUNT(*) FROM answers
+WHERE id = ?
+FOR SHARE
+`
+
+func (q *Queries) CheckIfAnswerExists(ctx context.Context, id int32) (int64, error) {
+	row := q.queryRow(ctx, q.checkIfAnswerExistsStmt, checkIfAnswerExists, id)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUpvotedAnswersByQuestionId = `-- name: CountUpvotedAnswersByQuestionId :one
+SELECT COUNT(*) FROM answers
+WHERE question_id = ? AND votes > 0
+FOR SHARE
+`
+
+func (q *Queries) CountUpvotedAnswersByQuestionId(ctx context.Context, questionID int32) (int64, error) {
+	row := q.queryRow(ctx, q.countUpvotedAnswersByQuestionIdStmt, countUpvotedAnswersByQuestionId, questionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAnswer = `-- name: CreateAnswer :execlastid
 INSERT INTO answers (body, question_id, user_id, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?)
 `
@@ -23,15 +50,18 @@ type CreateAnswerParams struct {
 	UpdatedAt  time.Time
 }
 
-func (q *Queries) CreateAnswer(ctx context.Context, arg CreateAnswerParams) error {
-	_, err := q.exec(ctx, q.createAnswerStmt, createAnswer,
+func (q *Queries) CreateAnswer(ctx context.Context, arg CreateAnswerParams) (int64, error) {
+	result, err := q.exec(ctx, q.createAnswerStmt, createAnswer,
 		arg.Body,
 		arg.QuestionID,
 		arg.UserID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
 }
 
 const deleteAnswer = `-- name: DeleteAnswer :exec
@@ -49,6 +79,38 @@ func (q *Queries) DeleteAnswer(ctx context.Context, arg DeleteAnswerParams) erro
 	return err
 }
 
+const getAllAnswerTexts = `-- name: GetAllAnswerTexts :many
+SELECT question_id, body FROM answers
+`
+
+type GetAllAnswerTextsRow struct {
+	QuestionID int32
+	Body       string
+}
+
+func (q *Queries) GetAllAnswerTexts(ctx context.Context) ([]GetAllAnswerTextsRow, error) {
+	rows, err := q.query(ctx, q.getAllAnswerTextsStmt, getAllAnswerTexts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllAnswerTextsRow
+	for rows.Next() {
+		var i GetAllAnswerTextsRow
+		if err := rows.Scan(&i.QuestionID, &i.Body); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAnswerById = `-- name: GetAnswerById :one
 SELECT id, body, votes, question_id, user_id, created_at, updated_at FROM answers
 WHERE id = ?
@@ -70,22 +132,24 @@ func (q *Queries) GetAnswerById(ctx context.Context, id int32) (Answer, error) {
 }
 
 const getAnswersByQuestionId = `-- name: GetAnswersByQuestionId :many
-SELECT answers.id, answers.body, answers.votes, answers.question_id, answers.user_id, answers.created_at, answers.updated_at, ` + "`" + `name` + "`" + `, email FROM answers
+SELECT answers.id, answers.body, answers.votes, answers.question_id, answers.user_id, answers.created_at, answers.updated_at, ` + "`" + `name` + "`" + `, email, questions.accepted_answer_id FROM answers
 INNER JOIN users ON users.id = answers.user_id
+INNER JOIN questions ON questions.id = answers.question_id
 WHERE question_id = ?
-ORDER BY votes DESC, answers.updated_at ASC
+ORDER BY answers.id = questions.accepted_answer_id DESC, votes DESC, answers.updated_at ASC
 `
 
 type GetAnswersByQuestionIdRow struct {
-	ID         int32
-	Body       string
-	Votes      int32
-	QuestionID int32
-	UserID     int32
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Name       string
-	Email      string
+	ID               int32
+	Body             string
+	Votes            int32
+	QuestionID       int32
+	UserID           int32
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Name             string
+	Email            string
+	AcceptedAnswerID sql.NullInt32
 }
 
 func (q *Queries) GetAnswersByQuestionId(ctx context.Context, questionID int32) ([]GetAnswersByQuestionIdRow, error) {
@@ -107,6 +171,7 @@ func (q *Queries) GetAnswersByQuestionId(ctx context.Context, questionID int32)
 			&i.UpdatedAt,
 			&i.Name,
 			&i.Email,
+			&i.AcceptedAnswerID,
 		); err != nil {
 			return nil, err
 		}
@@ -158,6 +223,49 @@ func (q *Queries) GetAnswersByUserId(ctx context.Context, userID int32) ([]Answe
 	return items, nil
 }
 
+const getTopAnswerByQuestionId = `-- name: GetTopAnswerByQuestionId :one
+SELECT id, body, votes, question_id, user_id, created_at, updated_at FROM answers
+WHERE question_id = ? AND user_id != ? AND votes > 0
+ORDER BY votes DESC, created_at ASC
+LIMIT 1
+`
+
+type GetTopAnswerByQuestionIdParams struct {
+	QuestionID int32
+	UserID     int32
+}
+
+func (q *Queries) GetTopAnswerByQuestionId(ctx context.Context, arg GetTopAnswerByQuestionIdParams) (Answer, error) {
+	row := q.queryRow(ctx, q.getTopAnswerByQuestionIdStmt, getTopAnswerByQuestionId, arg.QuestionID, arg.UserID)
+	var i Answer
+	err := row.Scan(
+		&i.ID,
+		&i.Body,
+		&i.Votes,
+		&i.QuestionID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const reassignAnswers = `-- name: ReassignAnswers :exec
+UPDATE answers
+SET user_id = ?
+WHERE user_id = ?
+`
+
+type ReassignAnswersParams struct {
+	NewUserID int32
+	OldUserID int32
+}
+
+func (q *Queries) ReassignAnswers(ctx context.Context, arg ReassignAnswersParams) error {
+	_, err := q.exec(ctx, q.reassignAnswersStmt, reassignAnswers, arg.NewUserID, arg.OldUserID)
+	return err
+}
+
 const updateAnswer = `-- name: UpdateAnswer :exec
 UPDATE answers
 SET body = ?, updated_at = ?
@@ -24,9 +24,21 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.acceptAnswerStmt, err = db.PrepareContext(ctx, acceptAnswer); err != nil {
+		return nil, fmt.Errorf("error preparing query AcceptAnswer: %w", err)
+	}
+	if q.addQuestionTagStmt, err = db.PrepareContext(ctx, addQuestionTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddQuestionTag: %w", err)
+	}
+	if q.addToBountyStmt, err = db.PrepareContext(ctx, addToBounty); err != nil {
+		return nil, fmt.Errorf("error preparing query AddToBounty: %w", err)
+	}
 	if q.addUserCreditStmt, err = db.PrepareContext(ctx, addUserCredit); err != nil {
 		return nil, fmt.Errorf("error preparing query AddUserCredit: %w", err)
 	}
+	if q.checkIfAnswerExistsStmt, err = db.PrepareContext(ctx, checkIfAnswerExists); err != nil {
+		return nil, fmt.Errorf("error preparing query CheckIfAnswerExists: %w", err)
+	}
 	if q.checkIfEmailExistsStmt, err = db.PrepareContext(ctx, checkIfEmailExists); err != nil {
 		return nil, fmt.Errorf("error preparing query CheckIfEmailExists: %w", err)
 	}
@@ -39,24 +51,126 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.closeQuestionStmt, err = db.PrepareContext(ctx, closeQuestion); err != nil {
 		return nil, fmt.Errorf("error preparing query CloseQuestion: %w", err)
 	}
+	if q.countApiKeysByUserIdStmt, err = db.PrepareContext(ctx, countApiKeysByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query CountApiKeysByUserId: %w", err)
+	}
+	if q.countUpvotedAnswersByQuestionIdStmt, err = db.PrepareContext(ctx, countUpvotedAnswersByQuestionId); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUpvotedAnswersByQuestionId: %w", err)
+	}
+	if q.createActiveTokenStmt, err = db.PrepareContext(ctx, createActiveToken); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateActiveToken: %w", err)
+	}
 	if q.createAnswerStmt, err = db.PrepareContext(ctx, createAnswer); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAnswer: %w", err)
 	}
+	if q.createApiKeyStmt, err = db.PrepareContext(ctx, createApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateApiKey: %w", err)
+	}
+	if q.createAuditEventStmt, err = db.PrepareContext(ctx, createAuditEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuditEvent: %w", err)
+	}
+	if q.createBountyStmt, err = db.PrepareContext(ctx, createBounty); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateBounty: %w", err)
+	}
+	if q.createEmailVerificationTokenStmt, err = db.PrepareContext(ctx, createEmailVerificationToken); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateEmailVerificationToken: %w", err)
+	}
+	if q.createLoginAttemptStmt, err = db.PrepareContext(ctx, createLoginAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLoginAttempt: %w", err)
+	}
+	if q.createModerationActionStmt, err = db.PrepareContext(ctx, createModerationAction); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateModerationAction: %w", err)
+	}
+	if q.createPasswordResetTokenStmt, err = db.PrepareContext(ctx, createPasswordResetToken); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePasswordResetToken: %w", err)
+	}
 	if q.createQuestionStmt, err = db.PrepareContext(ctx, createQuestion); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateQuestion: %w", err)
 	}
+	if q.createRecoveryCodeStmt, err = db.PrepareContext(ctx, createRecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRecoveryCode: %w", err)
+	}
+	if q.createRefreshTokenStmt, err = db.PrepareContext(ctx, createRefreshToken); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRefreshToken: %w", err)
+	}
+	if q.createRevisionStmt, err = db.PrepareContext(ctx, createRevision); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRevision: %w", err)
+	}
+	if q.createTagStmt, err = db.PrepareContext(ctx, createTag); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTag: %w", err)
+	}
 	if q.createVoteStmt, err = db.PrepareContext(ctx, createVote); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateVote: %w", err)
 	}
+	if q.deleteActiveTokenStmt, err = db.PrepareContext(ctx, deleteActiveToken); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteActiveToken: %w", err)
+	}
+	if q.deleteActiveTokenByJtiStmt, err = db.PrepareContext(ctx, deleteActiveTokenByJti); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteActiveTokenByJti: %w", err)
+	}
+	if q.deleteActiveTokensBySubStmt, err = db.PrepareContext(ctx, deleteActiveTokensBySub); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteActiveTokensBySub: %w", err)
+	}
 	if q.deleteAnswerStmt, err = db.PrepareContext(ctx, deleteAnswer); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAnswer: %w", err)
 	}
+	if q.deleteApiKeyStmt, err = db.PrepareContext(ctx, deleteApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteApiKey: %w", err)
+	}
+	if q.deleteExpiredChallengeTokensStmt, err = db.PrepareContext(ctx, deleteExpiredChallengeTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredChallengeTokens: %w", err)
+	}
+	if q.deleteLoginAttemptStmt, err = db.PrepareContext(ctx, deleteLoginAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLoginAttempt: %w", err)
+	}
+	if q.deleteOtherActiveTokensStmt, err = db.PrepareContext(ctx, deleteOtherActiveTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOtherActiveTokens: %w", err)
+	}
 	if q.deleteQuestionStmt, err = db.PrepareContext(ctx, deleteQuestion); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteQuestion: %w", err)
 	}
+	if q.deleteQuestionTagsStmt, err = db.PrepareContext(ctx, deleteQuestionTags); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteQuestionTags: %w", err)
+	}
+	if q.deleteRecoveryCodesStmt, err = db.PrepareContext(ctx, deleteRecoveryCodes); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRecoveryCodes: %w", err)
+	}
+	if q.deleteStaleLoginAttemptsStmt, err = db.PrepareContext(ctx, deleteStaleLoginAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStaleLoginAttempts: %w", err)
+	}
+	if q.deleteUserStmt, err = db.PrepareContext(ctx, deleteUser); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUser: %w", err)
+	}
+	if q.disableUserTotpStmt, err = db.PrepareContext(ctx, disableUserTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query DisableUserTotp: %w", err)
+	}
 	if q.downvoteStmt, err = db.PrepareContext(ctx, downvote); err != nil {
 		return nil, fmt.Errorf("error preparing query Downvote: %w", err)
 	}
+	if q.enableUserTotpStmt, err = db.PrepareContext(ctx, enableUserTotp); err != nil {
+		return nil, fmt.Errorf("error preparing query EnableUserTotp: %w", err)
+	}
+	if q.fullTextSearchQuestionsStmt, err = db.PrepareContext(ctx, fullTextSearchQuestions); err != nil {
+		return nil, fmt.Errorf("error preparing query FullTextSearchQuestions: %w", err)
+	}
+	if q.getAcceptedAnswerIdForUpdateStmt, err = db.PrepareContext(ctx, getAcceptedAnswerIdForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAcceptedAnswerIdForUpdate: %w", err)
+	}
+	if q.getActiveTokenIdStmt, err = db.PrepareContext(ctx, getActiveTokenId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveTokenId: %w", err)
+	}
+	if q.getActiveTokensBySubStmt, err = db.PrepareContext(ctx, getActiveTokensBySub); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveTokensBySub: %w", err)
+	}
+	if q.getAllAnswerTextsStmt, err = db.PrepareContext(ctx, getAllAnswerTexts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAllAnswerTexts: %w", err)
+	}
+	if q.getAllQuestionTextsStmt, err = db.PrepareContext(ctx, getAllQuestionTexts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAllQuestionTexts: %w", err)
+	}
+	if q.getAllQuestionsByUserIdStmt, err = db.PrepareContext(ctx, getAllQuestionsByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAllQuestionsByUserId: %w", err)
+	}
 	if q.getAnswerByIdStmt, err = db.PrepareContext(ctx, getAnswerById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAnswerById: %w", err)
 	}
@@ -66,24 +180,135 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAnswersByUserIdStmt, err = db.PrepareContext(ctx, getAnswersByUserId); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAnswersByUserId: %w", err)
 	}
+	if q.getApiKeyByHashStmt, err = db.PrepareContext(ctx, getApiKeyByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetApiKeyByHash: %w", err)
+	}
+	if q.getApiKeysByUserIdStmt, err = db.PrepareContext(ctx, getApiKeysByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetApiKeysByUserId: %w", err)
+	}
+	if q.getAuditEventsStmt, err = db.PrepareContext(ctx, getAuditEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAuditEvents: %w", err)
+	}
+	if q.getAuditEventsByUserIdStmt, err = db.PrepareContext(ctx, getAuditEventsByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAuditEventsByUserId: %w", err)
+	}
+	if q.getBountiesByUserIdStmt, err = db.PrepareContext(ctx, getBountiesByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBountiesByUserId: %w", err)
+	}
+	if q.getEmailVerificationTokenStmt, err = db.PrepareContext(ctx, getEmailVerificationToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEmailVerificationToken: %w", err)
+	}
+	if q.getExpiredBountiesStmt, err = db.PrepareContext(ctx, getExpiredBounties); err != nil {
+		return nil, fmt.Errorf("error preparing query GetExpiredBounties: %w", err)
+	}
+	if q.getLoginAttemptForUpdateStmt, err = db.PrepareContext(ctx, getLoginAttemptForUpdate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoginAttemptForUpdate: %w", err)
+	}
+	if q.getModerationActionsStmt, err = db.PrepareContext(ctx, getModerationActions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetModerationActions: %w", err)
+	}
+	if q.getModerationActionsByTargetUserIdStmt, err = db.PrepareContext(ctx, getModerationActionsByTargetUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetModerationActionsByTargetUserId: %w", err)
+	}
+	if q.getOpenBountiesByUserIdStmt, err = db.PrepareContext(ctx, getOpenBountiesByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenBountiesByUserId: %w", err)
+	}
+	if q.getOpenBountyByQuestionIdStmt, err = db.PrepareContext(ctx, getOpenBountyByQuestionId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenBountyByQuestionId: %w", err)
+	}
+	if q.getPasswordResetTokenStmt, err = db.PrepareContext(ctx, getPasswordResetToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPasswordResetToken: %w", err)
+	}
 	if q.getQuestionByIdStmt, err = db.PrepareContext(ctx, getQuestionById); err != nil {
 		return nil, fmt.Errorf("error preparing query GetQuestionById: %w", err)
 	}
 	if q.getQuestionsByUserIdStmt, err = db.PrepareContext(ctx, getQuestionsByUserId); err != nil {
 		return nil, fmt.Errorf("error preparing query GetQuestionsByUserId: %w", err)
 	}
+	if q.getRefreshTokenStmt, err = db.PrepareContext(ctx, getRefreshToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRefreshToken: %w", err)
+	}
+	if q.getRevisionByIdStmt, err = db.PrepareContext(ctx, getRevisionById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRevisionById: %w", err)
+	}
+	if q.getRevisionsByAnswerIdStmt, err = db.PrepareContext(ctx, getRevisionsByAnswerId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRevisionsByAnswerId: %w", err)
+	}
+	if q.getRevisionsByEditorIdStmt, err = db.PrepareContext(ctx, getRevisionsByEditorId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRevisionsByEditorId: %w", err)
+	}
+	if q.getRevisionsByQuestionIdStmt, err = db.PrepareContext(ctx, getRevisionsByQuestionId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRevisionsByQuestionId: %w", err)
+	}
+	if q.getTagIdByNameStmt, err = db.PrepareContext(ctx, getTagIdByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTagIdByName: %w", err)
+	}
+	if q.getTagsStmt, err = db.PrepareContext(ctx, getTags); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTags: %w", err)
+	}
+	if q.getTagsByQuestionIdsStmt, err = db.PrepareContext(ctx, getTagsByQuestionIds); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTagsByQuestionIds: %w", err)
+	}
+	if q.getTopAnswerByQuestionIdStmt, err = db.PrepareContext(ctx, getTopAnswerByQuestionId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTopAnswerByQuestionId: %w", err)
+	}
+	if q.getUserByIdStmt, err = db.PrepareContext(ctx, getUserById); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserById: %w", err)
+	}
+	if q.getUserIdByEmailStmt, err = db.PrepareContext(ctx, getUserIdByEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserIdByEmail: %w", err)
+	}
 	if q.getUserPointsAndCreditsStmt, err = db.PrepareContext(ctx, getUserPointsAndCredits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserPointsAndCredits: %w", err)
 	}
+	if q.getUserProfileStmt, err = db.PrepareContext(ctx, getUserProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserProfile: %w", err)
+	}
+	if q.getUserRoleStmt, err = db.PrepareContext(ctx, getUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserRole: %w", err)
+	}
+	if q.getVotesByUserIdStmt, err = db.PrepareContext(ctx, getVotesByUserId); err != nil {
+		return nil, fmt.Errorf("error preparing query GetVotesByUserId: %w", err)
+	}
+	if q.invalidateEmailVerificationTokensStmt, err = db.PrepareContext(ctx, invalidateEmailVerificationTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query InvalidateEmailVerificationTokens: %w", err)
+	}
+	if q.invalidatePasswordResetTokensStmt, err = db.PrepareContext(ctx, invalidatePasswordResetTokens); err != nil {
+		return nil, fmt.Errorf("error preparing query InvalidatePasswordResetTokens: %w", err)
+	}
 	if q.loginStmt, err = db.PrepareContext(ctx, login); err != nil {
 		return nil, fmt.Errorf("error preparing query Login: %w", err)
 	}
+	if q.pseudonymizeAuditEventsStmt, err = db.PrepareContext(ctx, pseudonymizeAuditEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query PseudonymizeAuditEvents: %w", err)
+	}
+	if q.reassignAnswersStmt, err = db.PrepareContext(ctx, reassignAnswers); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignAnswers: %w", err)
+	}
+	if q.reassignModerationActionsStmt, err = db.PrepareContext(ctx, reassignModerationActions); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignModerationActions: %w", err)
+	}
+	if q.reassignQuestionsStmt, err = db.PrepareContext(ctx, reassignQuestions); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignQuestions: %w", err)
+	}
+	if q.reassignRevisionsStmt, err = db.PrepareContext(ctx, reassignRevisions); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignRevisions: %w", err)
+	}
+	if q.reassignVotesStmt, err = db.PrepareContext(ctx, reassignVotes); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignVotes: %w", err)
+	}
 	if q.registerUserStmt, err = db.PrepareContext(ctx, registerUser); err != nil {
 		return nil, fmt.Errorf("error preparing query RegisterUser: %w", err)
 	}
+	if q.removeEmailFromAuditEventsStmt, err = db.PrepareContext(ctx, removeEmailFromAuditEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveEmailFromAuditEvents: %w", err)
+	}
 	if q.removeUserCreditStmt, err = db.PrepareContext(ctx, removeUserCredit); err != nil {
 		return nil, fmt.Errorf("error preparing query RemoveUserCredit: %w", err)
 	}
+	if q.resetQuestionPriorityStmt, err = db.PrepareContext(ctx, resetQuestionPriority); err != nil {
+		return nil, fmt.Errorf("error preparing query ResetQuestionPriority: %w", err)
+	}
 	if q.respondToQuestionStmt, err = db.PrepareContext(ctx, respondToQuestion); err != nil {
 		return nil, fmt.Errorf("error preparing query RespondToQuestion: %w", err)
 	}
@@ -93,6 +318,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.setActiveTokenStmt, err = db.PrepareContext(ctx, setActiveToken); err != nil {
 		return nil, fmt.Errorf("error preparing query SetActiveToken: %w", err)
 	}
+	if q.setLoginAttemptStmt, err = db.PrepareContext(ctx, setLoginAttempt); err != nil {
+		return nil, fmt.Errorf("error preparing query SetLoginAttempt: %w", err)
+	}
+	if q.setUserTotpSecretStmt, err = db.PrepareContext(ctx, setUserTotpSecret); err != nil {
+		return nil, fmt.Errorf("error preparing query SetUserTotpSecret: %w", err)
+	}
+	if q.settleBountyStmt, err = db.PrepareContext(ctx, settleBounty); err != nil {
+		return nil, fmt.Errorf("error preparing query SettleBounty: %w", err)
+	}
+	if q.touchApiKeyStmt, err = db.PrepareContext(ctx, touchApiKey); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchApiKey: %w", err)
+	}
 	if q.updateAnswerStmt, err = db.PrepareContext(ctx, updateAnswer); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateAnswer: %w", err)
 	}
@@ -102,22 +339,72 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateQuestionStmt, err = db.PrepareContext(ctx, updateQuestion); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateQuestion: %w", err)
 	}
+	if q.updateUserPasswordStmt, err = db.PrepareContext(ctx, updateUserPassword); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserPassword: %w", err)
+	}
 	if q.updateUserPointsStmt, err = db.PrepareContext(ctx, updateUserPoints); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateUserPoints: %w", err)
 	}
+	if q.updateUserProfileStmt, err = db.PrepareContext(ctx, updateUserProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserProfile: %w", err)
+	}
+	if q.updateUserRoleStmt, err = db.PrepareContext(ctx, updateUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateUserRole: %w", err)
+	}
 	if q.upvoteStmt, err = db.PrepareContext(ctx, upvote); err != nil {
 		return nil, fmt.Errorf("error preparing query Upvote: %w", err)
 	}
+	if q.useChallengeTokenStmt, err = db.PrepareContext(ctx, useChallengeToken); err != nil {
+		return nil, fmt.Errorf("error preparing query UseChallengeToken: %w", err)
+	}
+	if q.useEmailVerificationTokenStmt, err = db.PrepareContext(ctx, useEmailVerificationToken); err != nil {
+		return nil, fmt.Errorf("error preparing query UseEmailVerificationToken: %w", err)
+	}
+	if q.usePasswordResetTokenStmt, err = db.PrepareContext(ctx, usePasswordResetToken); err != nil {
+		return nil, fmt.Errorf("error preparing query UsePasswordResetToken: %w", err)
+	}
+	if q.useRecoveryCodeStmt, err = db.PrepareContext(ctx, useRecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query UseRecoveryCode: %w", err)
+	}
+	if q.useRefreshTokenStmt, err = db.PrepareContext(ctx, useRefreshToken); err != nil {
+		return nil, fmt.Errorf("error preparing query UseRefreshToken: %w", err)
+	}
+	if q.useUserTotpCounterStmt, err = db.PrepareContext(ctx, useUserTotpCounter); err != nil {
+		return nil, fmt.Errorf("error preparing query UseUserTotpCounter: %w", err)
+	}
+	if q.verifyUserEmailStmt, err = db.PrepareContext(ctx, verifyUserEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query VerifyUserEmail: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.acceptAnswerStmt != nil {
+		if cerr := q.acceptAnswerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing acceptAnswerStmt: %w", cerr)
+		}
+	}
+	if q.addQuestionTagStmt != nil {
+		if cerr := q.addQuestionTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addQuestionTagStmt: %w", cerr)
+		}
+	}
+	if q.addToBountyStmt != nil {
+		if cerr := q.addToBountyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addToBountyStmt: %w", cerr)
+		}
+	}
 	if q.addUserCreditStmt != nil {
 		if cerr := q.addUserCreditStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addUserCreditStmt: %w", cerr)
 		}
 	}
+	if q.checkIfAnswerExistsStmt != nil {
+		if cerr := q.checkIfAnswerExistsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing checkIfAnswerExistsStmt: %w", cerr)
+		}
+	}
 	if q.checkIfEmailExistsStmt != nil {
 		if cerr := q.checkIfEmailExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing checkIfEmailExistsStmt: %w", cerr)
@@ -138,36 +425,206 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing closeQuestionStmt: %w", cerr)
 		}
 	}
+	if q.countApiKeysByUserIdStmt != nil {
+		if cerr := q.countApiKeysByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countApiKeysByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.countUpvotedAnswersByQuestionIdStmt != nil {
+		if cerr := q.countUpvotedAnswersByQuestionIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUpvotedAnswersByQuestionIdStmt: %w", cerr)
+		}
+	}
+	if q.createActiveTokenStmt != nil {
+		if cerr := q.createActiveTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createActiveTokenStmt: %w", cerr)
+		}
+	}
 	if q.createAnswerStmt != nil {
 		if cerr := q.createAnswerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAnswerStmt: %w", cerr)
 		}
 	}
+	if q.createApiKeyStmt != nil {
+		if cerr := q.createApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createApiKeyStmt: %w", cerr)
+		}
+	}
+	if q.createAuditEventStmt != nil {
+		if cerr := q.createAuditEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuditEventStmt: %w", cerr)
+		}
+	}
+	if q.createBountyStmt != nil {
+		if cerr := q.createBountyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createBountyStmt: %w", cerr)
+		}
+	}
+	if q.createEmailVerificationTokenStmt != nil {
+		if cerr := q.createEmailVerificationTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createEmailVerificationTokenStmt: %w", cerr)
+		}
+	}
+	if q.createLoginAttemptStmt != nil {
+		if cerr := q.createLoginAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLoginAttemptStmt: %w", cerr)
+		}
+	}
+	if q.createModerationActionStmt != nil {
+		if cerr := q.createModerationActionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createModerationActionStmt: %w", cerr)
+		}
+	}
+	if q.createPasswordResetTokenStmt != nil {
+		if cerr := q.createPasswordResetTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPasswordResetTokenStmt: %w", cerr)
+		}
+	}
 	if q.createQuestionStmt != nil {
 		if cerr := q.createQuestionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createQuestionStmt: %w", cerr)
 		}
 	}
+	if q.createRecoveryCodeStmt != nil {
+		if cerr := q.createRecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.createRefreshTokenStmt != nil {
+		if cerr := q.createRefreshTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRefreshTokenStmt: %w", cerr)
+		}
+	}
+	if q.createRevisionStmt != nil {
+		if cerr := q.createRevisionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRevisionStmt: %w", cerr)
+		}
+	}
+	if q.createTagStmt != nil {
+		if cerr := q.createTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTagStmt: %w", cerr)
+		}
+	}
 	if q.createVoteStmt != nil {
 		if cerr := q.createVoteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createVoteStmt: %w", cerr)
 		}
 	}
+	if q.deleteActiveTokenStmt != nil {
+		if cerr := q.deleteActiveTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteActiveTokenStmt: %w", cerr)
+		}
+	}
+	if q.deleteActiveTokenByJtiStmt != nil {
+		if cerr := q.deleteActiveTokenByJtiStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteActiveTokenByJtiStmt: %w", cerr)
+		}
+	}
+	if q.deleteActiveTokensBySubStmt != nil {
+		if cerr := q.deleteActiveTokensBySubStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteActiveTokensBySubStmt: %w", cerr)
+		}
+	}
 	if q.deleteAnswerStmt != nil {
 		if cerr := q.deleteAnswerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAnswerStmt: %w", cerr)
 		}
 	}
+	if q.deleteApiKeyStmt != nil {
+		if cerr := q.deleteApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteApiKeyStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredChallengeTokensStmt != nil {
+		if cerr := q.deleteExpiredChallengeTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredChallengeTokensStmt: %w", cerr)
+		}
+	}
+	if q.deleteLoginAttemptStmt != nil {
+		if cerr := q.deleteLoginAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLoginAttemptStmt: %w", cerr)
+		}
+	}
+	if q.deleteOtherActiveTokensStmt != nil {
+		if cerr := q.deleteOtherActiveTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOtherActiveTokensStmt: %w", cerr)
+		}
+	}
 	if q.deleteQuestionStmt != nil {
 		if cerr := q.deleteQuestionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteQuestionStmt: %w", cerr)
 		}
 	}
+	if q.deleteQuestionTagsStmt != nil {
+		if cerr := q.deleteQuestionTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteQuestionTagsStmt: %w", cerr)
+		}
+	}
+	if q.deleteRecoveryCodesStmt != nil {
+		if cerr := q.deleteRecoveryCodesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRecoveryCodesStmt: %w", cerr)
+		}
+	}
+	if q.deleteStaleLoginAttemptsStmt != nil {
+		if cerr := q.deleteStaleLoginAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStaleLoginAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserStmt != nil {
+		if cerr := q.deleteUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserStmt: %w", cerr)
+		}
+	}
+	if q.disableUserTotpStmt != nil {
+		if cerr := q.disableUserTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing disableUserTotpStmt: %w", cerr)
+		}
+	}
 	if q.downvoteStmt != nil {
 		if cerr := q.downvoteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing downvoteStmt: %w", cerr)
 		}
 	}
+	if q.enableUserTotpStmt != nil {
+		if cerr := q.enableUserTotpStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enableUserTotpStmt: %w", cerr)
+		}
+	}
+	if q.fullTextSearchQuestionsStmt != nil {
+		if cerr := q.fullTextSearchQuestionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing fullTextSearchQuestionsStmt: %w", cerr)
+		}
+	}
+	if q.getAcceptedAnswerIdForUpdateStmt != nil {
+		if cerr := q.getAcceptedAnswerIdForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAcceptedAnswerIdForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getActiveTokenIdStmt != nil {
+		if cerr := q.getActiveTokenIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveTokenIdStmt: %w", cerr)
+		}
+	}
+	if q.getActiveTokensBySubStmt != nil {
+		if cerr := q.getActiveTokensBySubStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveTokensBySubStmt: %w", cerr)
+		}
+	}
+	if q.getAllAnswerTextsStmt != nil {
+		if cerr := q.getAllAnswerTextsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAllAnswerTextsStmt: %w", cerr)
+		}
+	}
+	if q.getAllQuestionTextsStmt != nil {
+		if cerr := q.getAllQuestionTextsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAllQuestionTextsStmt: %w", cerr)
+		}
+	}
+	if q.getAllQuestionsByUserIdStmt != nil {
+		if cerr := q.getAllQuestionsByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAllQuestionsByUserIdStmt: %w", cerr)
+		}
+	}
 	if q.getAnswerByIdStmt != nil {
 		if cerr := q.getAnswerByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAnswerByIdStmt: %w", cerr)
@@ -183,6 +640,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAnswersByUserIdStmt: %w", cerr)
 		}
 	}
+	if q.getApiKeyByHashStmt != nil {
+		if cerr := q.getApiKeyByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getApiKeyByHashStmt: %w", cerr)
+		}
+	}
+	if q.getApiKeysByUserIdStmt != nil {
+		if cerr := q.getApiKeysByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getApiKeysByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.getAuditEventsStmt != nil {
+		if cerr := q.getAuditEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAuditEventsStmt: %w", cerr)
+		}
+	}
+	if q.getAuditEventsByUserIdStmt != nil {
+		if cerr := q.getAuditEventsByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAuditEventsByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.getBountiesByUserIdStmt != nil {
+		if cerr := q.getBountiesByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBountiesByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.getEmailVerificationTokenStmt != nil {
+		if cerr := q.getEmailVerificationTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEmailVerificationTokenStmt: %w", cerr)
+		}
+	}
+	if q.getExpiredBountiesStmt != nil {
+		if cerr := q.getExpiredBountiesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getExpiredBountiesStmt: %w", cerr)
+		}
+	}
+	if q.getLoginAttemptForUpdateStmt != nil {
+		if cerr := q.getLoginAttemptForUpdateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoginAttemptForUpdateStmt: %w", cerr)
+		}
+	}
+	if q.getModerationActionsStmt != nil {
+		if cerr := q.getModerationActionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getModerationActionsStmt: %w", cerr)
+		}
+	}
+	if q.getModerationActionsByTargetUserIdStmt != nil {
+		if cerr := q.getModerationActionsByTargetUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getModerationActionsByTargetUserIdStmt: %w", cerr)
+		}
+	}
+	if q.getOpenBountiesByUserIdStmt != nil {
+		if cerr := q.getOpenBountiesByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenBountiesByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.getOpenBountyByQuestionIdStmt != nil {
+		if cerr := q.getOpenBountyByQuestionIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenBountyByQuestionIdStmt: %w", cerr)
+		}
+	}
+	if q.getPasswordResetTokenStmt != nil {
+		if cerr := q.getPasswordResetTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPasswordResetTokenStmt: %w", cerr)
+		}
+	}
 	if q.getQuestionByIdStmt != nil {
 		if cerr := q.getQuestionByIdStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getQuestionByIdStmt: %w", cerr)
@@ -193,26 +715,146 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getQuestionsByUserIdStmt: %w", cerr)
 		}
 	}
+	if q.getRefreshTokenStmt != nil {
+		if cerr := q.getRefreshTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRefreshTokenStmt: %w", cerr)
+		}
+	}
+	if q.getRevisionByIdStmt != nil {
+		if cerr := q.getRevisionByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRevisionByIdStmt: %w", cerr)
+		}
+	}
+	if q.getRevisionsByAnswerIdStmt != nil {
+		if cerr := q.getRevisionsByAnswerIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRevisionsByAnswerIdStmt: %w", cerr)
+		}
+	}
+	if q.getRevisionsByEditorIdStmt != nil {
+		if cerr := q.getRevisionsByEditorIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRevisionsByEditorIdStmt: %w", cerr)
+		}
+	}
+	if q.getRevisionsByQuestionIdStmt != nil {
+		if cerr := q.getRevisionsByQuestionIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRevisionsByQuestionIdStmt: %w", cerr)
+		}
+	}
+	if q.getTagIdByNameStmt != nil {
+		if cerr := q.getTagIdByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagIdByNameStmt: %w", cerr)
+		}
+	}
+	if q.getTagsStmt != nil {
+		if cerr := q.getTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagsStmt: %w", cerr)
+		}
+	}
+	if q.getTagsByQuestionIdsStmt != nil {
+		if cerr := q.getTagsByQuestionIdsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTagsByQuestionIdsStmt: %w", cerr)
+		}
+	}
+	if q.getTopAnswerByQuestionIdStmt != nil {
+		if cerr := q.getTopAnswerByQuestionIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTopAnswerByQuestionIdStmt: %w", cerr)
+		}
+	}
+	if q.getUserByIdStmt != nil {
+		if cerr := q.getUserByIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserByIdStmt: %w", cerr)
+		}
+	}
+	if q.getUserIdByEmailStmt != nil {
+		if cerr := q.getUserIdByEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserIdByEmailStmt: %w", cerr)
+		}
+	}
 	if q.getUserPointsAndCreditsStmt != nil {
 		if cerr := q.getUserPointsAndCreditsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserPointsAndCreditsStmt: %w", cerr)
 		}
 	}
+	if q.getUserProfileStmt != nil {
+		if cerr := q.getUserProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserProfileStmt: %w", cerr)
+		}
+	}
+	if q.getUserRoleStmt != nil {
+		if cerr := q.getUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.getVotesByUserIdStmt != nil {
+		if cerr := q.getVotesByUserIdStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getVotesByUserIdStmt: %w", cerr)
+		}
+	}
+	if q.invalidateEmailVerificationTokensStmt != nil {
+		if cerr := q.invalidateEmailVerificationTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing invalidateEmailVerificationTokensStmt: %w", cerr)
+		}
+	}
+	if q.invalidatePasswordResetTokensStmt != nil {
+		if cerr := q.invalidatePasswordResetTokensStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing invalidatePasswordResetTokensStmt: %w", cerr)
+		}
+	}
 	if q.loginStmt != nil {
 		if cerr := q.loginStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing loginStmt: %w", cerr)
 		}
 	}
+	if q.pseudonymizeAuditEventsStmt != nil {
+		if cerr := q.pseudonymizeAuditEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing pseudonymizeAuditEventsStmt: %w", cerr)
+		}
+	}
+	if q.reassignAnswersStmt != nil {
+		if cerr := q.reassignAnswersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignAnswersStmt: %w", cerr)
+		}
+	}
+	if q.reassignModerationActionsStmt != nil {
+		if cerr := q.reassignModerationActionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignModerationActionsStmt: %w", cerr)
+		}
+	}
+	if q.reassignQuestionsStmt != nil {
+		if cerr := q.reassignQuestionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignQuestionsStmt: %w", cerr)
+		}
+	}
+	if q.reassignRevisionsStmt != nil {
+		if cerr := q.reassignRevisionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignRevisionsStmt: %w", cerr)
+		}
+	}
+	if q.reassignVotesStmt != nil {
+		if cerr := q.reassignVotesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignVotesStmt: %w", cerr)
+		}
+	}
 	if q.registerUserStmt != nil {
 		if cerr := q.registerUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing registerUserStmt: %w", cerr)
 		}
 	}
+	if q.removeEmailFromAuditEventsStmt != nil {
+		if cerr := q.removeEmailFromAuditEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeEmailFromAuditEventsStmt: %w", cerr)
+		}
+	}
 	if q.removeUserCreditStmt != nil {
 		if cerr := q.removeUserCreditStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing removeUserCreditStmt: %w", cerr)
 		}
 	}
+	if q.resetQuestionPriorityStmt != nil {
+		if cerr := q.resetQuestionPriorityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resetQuestionPriorityStmt: %w", cerr)
+		}
+	}
 	if q.respondToQuestionStmt != nil {
 		if cerr := q.respondToQuestionStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing respondToQuestionStmt: %w", cerr)
@@ -228,6 +870,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing setActiveTokenStmt: %w", cerr)
 		}
 	}
+	if q.setLoginAttemptStmt != nil {
+		if cerr := q.setLoginAttemptStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setLoginAttemptStmt: %w", cerr)
+		}
+	}
+	if q.setUserTotpSecretStmt != nil {
+		if cerr := q.setUserTotpSecretStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setUserTotpSecretStmt: %w", cerr)
+		}
+	}
+	if q.settleBountyStmt != nil {
+		if cerr := q.settleBountyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing settleBountyStmt: %w", cerr)
+		}
+	}
+	if q.touchApiKeyStmt != nil {
+		if cerr := q.touchApiKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchApiKeyStmt: %w", cerr)
+		}
+	}
 	if q.updateAnswerStmt != nil {
 		if cerr := q.updateAnswerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateAnswerStmt: %w", cerr)
@@ -243,16 +905,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateQuestionStmt: %w", cerr)
 		}
 	}
+	if q.updateUserPasswordStmt != nil {
+		if cerr := q.updateUserPasswordStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserPasswordStmt: %w", cerr)
+		}
+	}
 	if q.updateUserPointsStmt != nil {
 		if cerr := q.updateUserPointsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateUserPointsStmt: %w", cerr)
 		}
 	}
+	if q.updateUserProfileStmt != nil {
+		if cerr := q.updateUserProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserProfileStmt: %w", cerr)
+		}
+	}
+	if q.updateUserRoleStmt != nil {
+		if cerr := q.updateUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateUserRoleStmt: %w", cerr)
+		}
+	}
 	if q.upvoteStmt != nil {
 		if cerr := q.upvoteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upvoteStmt: %w", cerr)
 		}
 	}
+	if q.useChallengeTokenStmt != nil {
+		if cerr := q.useChallengeTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useChallengeTokenStmt: %w", cerr)
+		}
+	}
+	if q.useEmailVerificationTokenStmt != nil {
+		if cerr := q.useEmailVerificationTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useEmailVerificationTokenStmt: %w", cerr)
+		}
+	}
+	if q.usePasswordResetTokenStmt != nil {
+		if cerr := q.usePasswordResetTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing usePasswordResetTokenStmt: %w", cerr)
+		}
+	}
+	if q.useRecoveryCodeStmt != nil {
+		if cerr := q.useRecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useRecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.useRefreshTokenStmt != nil {
+		if cerr := q.useRefreshTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useRefreshTokenStmt: %w", cerr)
+		}
+	}
+	if q.useUserTotpCounterStmt != nil {
+		if cerr := q.useUserTotpCounterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useUserTotpCounterStmt: %w", cerr)
+		}
+	}
+	if q.verifyUserEmailStmt != nil {
+		if cerr := q.verifyUserEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing verifyUserEmailStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -290,69 +1002,247 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                          DBTX
-	tx                          *sql.Tx
-	addUserCreditStmt           *sql.Stmt
-	checkIfEmailExistsStmt      *sql.Stmt
-	checkIfTokenIsActiveStmt    *sql.Stmt
-	checkIfVoteExistsStmt       *sql.Stmt
-	closeQuestionStmt           *sql.Stmt
-	createAnswerStmt            *sql.Stmt
-	createQuestionStmt          *sql.Stmt
-	createVoteStmt              *sql.Stmt
-	deleteAnswerStmt            *sql.Stmt
-	deleteQuestionStmt          *sql.Stmt
-	downvoteStmt                *sql.Stmt
-	getAnswerByIdStmt           *sql.Stmt
-	getAnswersByQuestionIdStmt  *sql.Stmt
-	getAnswersByUserIdStmt      *sql.Stmt
-	getQuestionByIdStmt         *sql.Stmt
-	getQuestionsByUserIdStmt    *sql.Stmt
-	getUserPointsAndCreditsStmt *sql.Stmt
-	loginStmt                   *sql.Stmt
-	registerUserStmt            *sql.Stmt
-	removeUserCreditStmt        *sql.Stmt
-	respondToQuestionStmt       *sql.Stmt
-	searchQuestionsStmt         *sql.Stmt
-	setActiveTokenStmt          *sql.Stmt
-	updateAnswerStmt            *sql.Stmt
-	updateAnswerVotesStmt       *sql.Stmt
-	updateQuestionStmt          *sql.Stmt
-	updateUserPointsStmt        *sql.Stmt
-	upvoteStmt                  *sql.Stmt
+	db                                     DBTX
+	tx                                     *sql.Tx
+	acceptAnswerStmt                       *sql.Stmt
+	addQuestionTagStmt                     *sql.Stmt
+	addToBountyStmt                        *sql.Stmt
+	addUserCreditStmt                      *sql.Stmt
+	checkIfAnswerExistsStmt                *sql.Stmt
+	checkIfEmailExistsStmt                 *sql.Stmt
+	checkIfTokenIsActiveStmt               *sql.Stmt
+	checkIfVoteExistsStmt                  *sql.Stmt
+	closeQuestionStmt                      *sql.Stmt
+	countApiKeysByUserIdStmt               *sql.Stmt
+	countUpvotedAnswersByQuestionIdStmt    *sql.Stmt
+	createActiveTokenStmt                  *sql.Stmt
+	createAnswerStmt                       *sql.Stmt
+	createApiKeyStmt                       *sql.Stmt
+	createAuditEventStmt                   *sql.Stmt
+	createBountyStmt                       *sql.Stmt
+	createEmailVerificationTokenStmt       *sql.Stmt
+	createLoginAttemptStmt                 *sql.Stmt
+	createModerationActionStmt             *sql.Stmt
+	createPasswordResetTokenStmt           *sql.Stmt
+	createQuestionStmt                     *sql.Stmt
+	createRecoveryCodeStmt                 *sql.Stmt
+	createRefreshTokenStmt                 *sql.Stmt
+	createRevisionStmt                     *sql.Stmt
+	createTagStmt                          *sql.Stmt
+	createVoteStmt                         *sql.Stmt
+	deleteActiveTokenStmt                  *sql.Stmt
+	deleteActiveTokenByJtiStmt             *sql.Stmt
+	deleteActiveTokensBySubStmt            *sql.Stmt
+	deleteAnswerStmt                       *sql.Stmt
+	deleteApiKeyStmt                       *sql.Stmt
+	deleteExpiredChallengeTokensStmt       *sql.Stmt
+	deleteLoginAttemptStmt                 *sql.Stmt
+	deleteOtherActiveTokensStmt            *sql.Stmt
+	deleteQuestionStmt                     *sql.Stmt
+	deleteQuestionTagsStmt                 *sql.Stmt
+	deleteRecoveryCodesStmt                *sql.Stmt
+	deleteStaleLoginAttemptsStmt           *sql.Stmt
+	deleteUserStmt                         *sql.Stmt
+	disableUserTotpStmt                    *sql.Stmt
+	downvoteStmt                           *sql.Stmt
+	enableUserTotpStmt                     *sql.Stmt
+	fullTextSearchQuestionsStmt            *sql.Stmt
+	getAcceptedAnswerIdForUpdateStmt       *sql.Stmt
+	getActiveTokenIdStmt                   *sql.Stmt
+	getActiveTokensBySubStmt               *sql.Stmt
+	getAllAnswerTextsStmt                  *sql.Stmt
+	getAllQuestionTextsStmt                *sql.Stmt
+	getAllQuestionsByUserIdStmt            *sql.Stmt
+	getAnswerByIdStmt                      *sql.Stmt
+	getAnswersByQuestionIdStmt             *sql.Stmt
+	getAnswersByUserIdStmt                 *sql.Stmt
+	getApiKeyByHashStmt                    *sql.Stmt
+	getApiKeysByUserIdStmt                 *sql.Stmt
+	getAuditEventsStmt                     *sql.Stmt
+	getAuditEventsByUserIdStmt             *sql.Stmt
+	getBountiesByUserIdStmt                *sql.Stmt
+	getEmailVerificationTokenStmt          *sql.Stmt
+	getExpiredBountiesStmt                 *sql.Stmt
+	getLoginAttemptForUpdateStmt           *sql.Stmt
+	getModerationActionsStmt               *sql.Stmt
+	getModerationActionsByTargetUserIdStmt *sql.Stmt
+	getOpenBountiesByUserIdStmt            *sql.Stmt
+	getOpenBountyByQuestionIdStmt          *sql.Stmt
+	getPasswordResetTokenStmt              *sql.Stmt
+	getQuestionByIdStmt                    *sql.Stmt
+	getQuestionsByUserIdStmt               *sql.Stmt
+	getRefreshTokenStmt                    *sql.Stmt
+	getRevisionByIdStmt                    *sql.Stmt
+	getRevisionsByAnswerIdStmt             *sql.Stmt
+	getRevisionsByEditorIdStmt             *sql.Stmt
+	getRevisionsByQuestionIdStmt           *sql.Stmt
+	getTagIdByNameStmt                     *sql.Stmt
+	getTagsStmt                            *sql.Stmt
+	getTagsByQuestionIdsStmt               *sql.Stmt
+	getTopAnswerByQuestionIdStmt           *sql.Stmt
+	getUserByIdStmt                        *sql.Stmt
+	getUserIdByEmailStmt                   *sql.Stmt
+	getUserPointsAndCreditsStmt            *sql.Stmt
+	getUserProfileStmt                     *sql.Stmt
+	getUserRoleStmt                        *sql.Stmt
+	getVotesByUserIdStmt                   *sql.Stmt
+	invalidateEmailVerificationTokensStmt  *sql.Stmt
+	invalidatePasswordResetTokensStmt      *sql.Stmt
+	loginStmt                              *sql.Stmt
+	pseudonymizeAuditEventsStmt            *sql.Stmt
+	reassignAnswersStmt                    *sql.Stmt
+	reassignModerationActionsStmt          *sql.Stmt
+	reassignQuestionsStmt                  *sql.Stmt
+	reassignRevisionsStmt                  *sql.Stmt
+	reassignVotesStmt                      *sql.Stmt
+	registerUserStmt                       *sql.Stmt
+	removeEmailFromAuditEventsStmt         *sql.Stmt
+	removeUserCreditStmt                   *sql.Stmt
+	resetQuestionPriorityStmt              *sql.Stmt
+	respondToQuestionStmt                  *sql.Stmt
+	searchQuestionsStmt                    *sql.Stmt
+	setActiveTokenStmt                     *sql.Stmt
+	setLoginAttemptStmt                    *sql.Stmt
+	setUserTotpSecretStmt                  *sql.Stmt
+	settleBountyStmt                       *sql.Stmt
+	touchApiKeyStmt                        *sql.Stmt
+	updateAnswerStmt                       *sql.Stmt
+	updateAnswerVotesStmt                  *sql.Stmt
+	updateQuestionStmt                     *sql.Stmt
+	updateUserPasswordStmt                 *sql.Stmt
+	updateUserPointsStmt                   *sql.Stmt
+	updateUserProfileStmt                  *sql.Stmt
+	updateUserRoleStmt                     *sql.Stmt
+	upvoteStmt                             *sql.Stmt
+	useChallengeTokenStmt                  *sql.Stmt
+	useEmailVerificationTokenStmt          *sql.Stmt
+	usePasswordResetTokenStmt              *sql.Stmt
+	useRecoveryCodeStmt                    *sql.Stmt
+	useRefreshTokenStmt                    *sql.Stmt
+	useUserTotpCounterStmt                 *sql.Stmt
+	verifyUserEmailStmt                    *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                          tx,
-		tx:                          tx,
-		addUserCreditStmt:           q.addUserCreditStmt,
-		checkIfEmailExistsStmt:      q.checkIfEmailExistsStmt,
-		checkIfTokenIsActiveStmt:    q.checkIfTokenIsActiveStmt,
-		checkIfVoteExistsStmt:       q.checkIfVoteExistsStmt,
-		closeQuestionStmt:           q.closeQuestionStmt,
-		createAnswerStmt:            q.createAnswerStmt,
-		createQuestionStmt:          q.createQuestionStmt,
-		createVoteStmt:              q.createVoteStmt,
-		deleteAnswerStmt:            q.deleteAnswerStmt,
-		deleteQuestionStmt:          q.deleteQuestionStmt,
-		downvoteStmt:                q.downvoteStmt,
-		getAnswerByIdStmt:           q.getAnswerByIdStmt,
-		getAnswersByQuestionIdStmt:  q.getAnswersByQuestionIdStmt,
-		getAnswersByUserIdStmt:      q.getAnswersByUserIdStmt,
-		getQuestionByIdStmt:         q.getQuestionByIdStmt,
-		getQuestionsByUserIdStmt:    q.getQuestionsByUserIdStmt,
-		getUserPointsAndCreditsStmt: q.getUserPointsAndCreditsStmt,
-		loginStmt:                   q.loginStmt,
-		registerUserStmt:            q.registerUserStmt,
-		removeUserCreditStmt:        q.removeUserCreditStmt,
-		respondToQuestionStmt:       q.respondToQuestionStmt,
-		searchQuestionsStmt:         q.searchQuestionsStmt,
-		setActiveTokenStmt:          q.setActiveTokenStmt,
-		updateAnswerStmt:            q.updateAnswerStmt,
-		updateAnswerVotesStmt:       q.updateAnswerVotesStmt,
-		updateQuestionStmt:          q.updateQuestionStmt,
-		updateUserPointsStmt:        q.updateUserPointsStmt,
-		upvoteStmt:                  q.upvoteStmt,
+		db:                                     tx,
+		tx:                                     tx,
+		acceptAnswerStmt:                       q.acceptAnswerStmt,
+		addQuestionTagStmt:                     q.addQuestionTagStmt,
+		addToBountyStmt:                        q.addToBountyStmt,
+		addUserCreditStmt:                      q.addUserCreditStmt,
+		checkIfAnswerExistsStmt:                q.checkIfAnswerExistsStmt,
+		checkIfEmailExistsStmt:                 q.checkIfEmailExistsStmt,
+		checkIfTokenIsActiveStmt:               q.checkIfTokenIsActiveStmt,
+		checkIfVoteExistsStmt:                  q.checkIfVoteExistsStmt,
+		closeQuestionStmt:                      q.closeQuestionStmt,
+		countApiKeysByUserIdStmt:               q.countApiKeysByUserIdStmt,
+		countUpvotedAnswersByQuestionIdStmt:    q.countUpvotedAnswersByQuestionIdStmt,
+		createActiveTokenStmt:                  q.createActiveTokenStmt,
+		createAnswerStmt:                       q.createAnswerStmt,
+		createApiKeyStmt:                       q.createApiKeyStmt,
+		createAuditEventStmt:                   q.createAuditEventStmt,
+		createBountyStmt:                       q.createBountyStmt,
+		createEmailVerificationTokenStmt:       q.createEmailVerificationTokenStmt,
+		createLoginAttemptStmt:                 q.createLoginAttemptStmt,
+		createModerationActionStmt:             q.createModerationActionStmt,
+		createPasswordResetTokenStmt:           q.createPasswordResetTokenStmt,
+		createQuestionStmt:                     q.createQuestionStmt,
+		createRecoveryCodeStmt:                 q.createRecoveryCodeStmt,
+		createRefreshTokenStmt:                 q.createRefreshTokenStmt,
+		createRevisionStmt:                     q.createRevisionStmt,
+		createTagStmt:                          q.createTagStmt,
+		createVoteStmt:                         q.createVoteStmt,
+		deleteActiveTokenStmt:                  q.deleteActiveTokenStmt,
+		deleteActiveTokenByJtiStmt:             q.deleteActiveTokenByJtiStmt,
+		deleteActiveTokensBySubStmt:            q.deleteActiveTokensBySubStmt,
+		deleteAnswerStmt:                       q.deleteAnswerStmt,
+		deleteApiKeyStmt:                       q.deleteApiKeyStmt,
+		deleteExpiredChallengeTokensStmt:       q.deleteExpiredChallengeTokensStmt,
+		deleteLoginAttemptStmt:                 q.deleteLoginAttemptStmt,
+		deleteOtherActiveTokensStmt:            q.deleteOtherActiveTokensStmt,
+		deleteQuestionStmt:                     q.deleteQuestionStmt,
+		deleteQuestionTagsStmt:                 q.deleteQuestionTagsStmt,
+		deleteRecoveryCodesStmt:                q.deleteRecoveryCodesStmt,
+		deleteStaleLoginAttemptsStmt:           q.deleteStaleLoginAttemptsStmt,
+		deleteUserStmt:                         q.deleteUserStmt,
+		disableUserTotpStmt:                    q.disableUserTotpStmt,
+		downvoteStmt:                           q.downvoteStmt,
+		enableUserTotpStmt:                     q.enableUserTotpStmt,
+		fullTextSearchQuestionsStmt:            q.fullTextSearchQuestionsStmt,
+		getAcceptedAnswerIdForUpdateStmt:       q.getAcceptedAnswerIdForUpdateStmt,
+		getActiveTokenIdStmt:                   q.getActiveTokenIdStmt,
+		getActiveTokensBySubStmt:               q.getActiveTokensBySubStmt,
+		getAllAnswerTextsStmt:                  q.getAllAnswerTextsStmt,
+		getAllQuestionTextsStmt:                q.getAllQuestionTextsStmt,
+		getAllQuestionsByUserIdStmt:            q.getAllQuestionsByUserIdStmt,
+		getAnswerByIdStmt:                      q.getAnswerByIdStmt,
+		getAnswersByQuestionIdStmt:             q.getAnswersByQuestionIdStmt,
+		getAnswersByUserIdStmt:                 q.getAnswersByUserIdStmt,
+		getApiKeyByHashStmt:                    q.getApiKeyByHashStmt,
+		getApiKeysByUserIdStmt:                 q.getApiKeysByUserIdStmt,
+		getAuditEventsStmt:                     q.getAuditEventsStmt,
+		getAuditEventsByUserIdStmt:             q.getAuditEventsByUserIdStmt,
+		getBountiesByUserIdStmt:                q.getBountiesByUserIdStmt,
+		getEmailVerificationTokenStmt:          q.getEmailVerificationTokenStmt,
+		getExpiredBountiesStmt:                 q.getExpiredBountiesStmt,
+		getLoginAttemptForUpdateStmt:           q.getLoginAttemptForUpdateStmt,
+		getModerationActionsStmt:               q.getModerationActionsStmt,
+		getModerationActionsByTargetUserIdStmt: q.getModerationActionsByTargetUserIdStmt,
+		getOpenBountiesByUserIdStmt:            q.getOpenBountiesByUserIdStmt,
+		getOpenBountyByQuestionIdStmt:          q.getOpenBountyByQuestionIdStmt,
+		getPasswordResetTokenStmt:              q.getPasswordResetTokenStmt,
+		getQuestionByIdStmt:                    q.getQuestionByIdStmt,
+		getQuestionsByUserIdStmt:               q.getQuestionsByUserIdStmt,
+		getRefreshTokenStmt:                    q.getRefreshTokenStmt,
+		getRevisionByIdStmt:                    q.getRevisionByIdStmt,
+		getRevisionsByAnswerIdStmt:             q.getRevisionsByAnswerIdStmt,
+		getRevisionsByEditorIdStmt:             q.getRevisionsByEditorIdStmt,
+		getRevisionsByQuestionIdStmt:           q.getRevisionsByQuestionIdStmt,
+		getTagIdByNameStmt:                     q.getTagIdByNameStmt,
+		getTagsStmt:                            q.getTagsStmt,
+		getTagsByQuestionIdsStmt:               q.getTagsByQuestionIdsStmt,
+		getTopAnswerByQuestionIdStmt:           q.getTopAnswerByQuestionIdStmt,
+		getUserByIdStmt:                        q.getUserByIdStmt,
+		getUserIdByEmailStmt:                   q.getUserIdByEmailStmt,
+		getUserPointsAndCreditsStmt:            q.getUserPointsAndCreditsStmt,
+		getUserProfileStmt:                     q.getUserProfileStmt,
+		getUserRoleStmt:                        q.getUserRoleStmt,
+		getVotesByUserIdStmt:                   q.getVotesByUserIdStmt,
+		invalidateEmailVerificationTokensStmt:  q.invalidateEmailVerificationTokensStmt,
+		invalidatePasswordResetTokensStmt:      q.invalidatePasswordResetTokensStmt,
+		loginStmt:                              q.loginStmt,
+		pseudonymizeAuditEventsStmt:            q.pseudonymizeAuditEventsStmt,
+		reassignAnswersStmt:                    q.reassignAnswersStmt,
+		reassignModerationActionsStmt:          q.reassignModerationActionsStmt,
+		reassignQuestionsStmt:                  q.reassignQuestionsStmt,
+		reassignRevisionsStmt:                  q.reassignRevisionsStmt,
+		reassignVotesStmt:                      q.reassignVotesStmt,
+		registerUserStmt:                       q.registerUserStmt,
+		removeEmailFromAuditEventsStmt:         q.removeEmailFromAuditEventsStmt,
+		removeUserCreditStmt:                   q.removeUserCreditStmt,
+		resetQuestionPriorityStmt:              q.resetQuestionPriorityStmt,
+		respondToQuestionStmt:                  q.respondToQuestionStmt,
+		searchQuestionsStmt:                    q.searchQuestionsStmt,
+		setActiveTokenStmt:                     q.setActiveTokenStmt,
+		setLoginAttemptStmt:                    q.setLoginAttemptStmt,
+		setUserTotpSecretStmt:                  q.setUserTotpSecretStmt,
+		settleBountyStmt:                       q.settleBountyStmt,
+		touchApiKeyStmt:                        q.touchApiKeyStmt,
+		updateAnswerStmt:                       q.updateAnswerStmt,
+		updateAnswerVotesStmt:                  q.updateAnswerVotesStmt,
+		updateQuestionStmt:                     q.updateQuestionStmt,
+		updateUserPasswordStmt:                 q.updateUserPasswordStmt,
+		updateUserPointsStmt:                   q.updateUserPointsStmt,
+		updateUserProfileStmt:                  q.updateUserProfileStmt,
+		updateUserRoleStmt:                     q.updateUserRoleStmt,
+		upvoteStmt:                             q.upvoteStmt,
+		useChallengeTokenStmt:                  q.useChallengeTokenStmt,
+		useEmailVerificationTokenStmt:          q.useEmailVerificationTokenStmt,
+		usePasswordResetTokenStmt:              q.usePasswordResetTokenStmt,
+		useRecoveryCodeStmt:                    q.useRecoveryCodeStmt,
+		useRefreshTokenStmt:                    q.useRefreshTokenStmt,
+		useUserTotpCounterStmt:                 q.useUserTotpCounterStmt,
+		verifyUserEmailStmt:                    q.verifyUserEmailStmt,
 	}
 }
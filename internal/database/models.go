@@ -5,12 +5,31 @@
 package database
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type ActiveToken struct {
-	Sub int32
-	Jti string
+	ID         int32
+	Sub        int32
+	Jti        string
+	DeviceName string
+	UserAgent  string
+	IpAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+type ApiKey struct {
+	ID         int32
+	UserID     int32
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scopes     string
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
 }
 
 type Answer struct {
@@ -23,27 +42,136 @@ type Answer struct {
 	UpdatedAt  time.Time
 }
 
+type AuditEvent struct {
+	ID        int64
+	ActorID   sql.NullInt32
+	Action    string
+	IpAddress string
+	UserAgent string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+type Bounty struct {
+	ID              int32
+	QuestionID      int32
+	Amount          int32
+	Status          string
+	AwardedAnswerID sql.NullInt32
+	ExpiresAt       time.Time
+	SettledAt       sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type EmailVerificationToken struct {
+	ID        int32
+	UserID    int32
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type LoginAttempt struct {
+	AttemptKey   string
+	Failures     int32
+	LastFailedAt time.Time
+	LockedUntil  sql.NullTime
+}
+
+type ModerationAction struct {
+	ID           int32
+	ModeratorID  int32
+	Action       string
+	TargetType   string
+	TargetID     int32
+	TargetUserID int32
+	CreatedAt    time.Time
+}
+
+type PasswordResetToken struct {
+	ID        int32
+	UserID    int32
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
 type Question struct {
-	ID            int32
-	Title         string
-	Body          string
-	PriorityLevel int32
-	UserID        int32
-	RespondedAt   time.Time
-	Closed        bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID               int32
+	Title            string
+	Body             string
+	PriorityLevel    int32
+	UserID           int32
+	RespondedAt      time.Time
+	Closed           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	AcceptedAnswerID sql.NullInt32
 }
 
-type User struct {
+type QuestionTag struct {
+	QuestionID int32
+	TagID      int32
+}
+
+type RecoveryCode struct {
+	ID        int32
+	UserID    int32
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type RefreshToken struct {
+	ID        int32
+	Jti       string
+	ParentJti sql.NullString
+	FamilyID  int32
+	Sub       int32
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
+type Revision struct {
+	ID         int32
+	QuestionID sql.NullInt32
+	AnswerID   sql.NullInt32
+	Title      sql.NullString
+	Body       string
+	EditorID   int32
+	CreatedAt  time.Time
+}
+
+type Tag struct {
 	ID        int32
 	Name      string
-	Email     string
-	Password  string
-	Points    int32
-	Credits   int32
 	CreatedAt time.Time
-	UpdatedAt time.Time
+}
+
+type UsedChallengeToken struct {
+	Jti       string
+	UserID    int32
+	ExpiresAt time.Time
+}
+
+type User struct {
+	ID              int32
+	Name            string
+	Email           string
+	Password        string
+	Points          int32
+	Credits         int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	VerifiedAt      sql.NullTime
+	TotpSecret      sql.NullString
+	TotpEnabled     bool
+	Role            string
+	Bio             string
+	TotpLastCounter int64
 }
 
 type Vote struct {
@@ -7,13 +7,41 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 )
 
-const closeQuestion = `-- name: CloseQuestion :exec
+const acceptAnswer = `-- name: AcceptAnswer :execrows
+UPDATE questions
+SET accepted_answer_id = ?, updated_at = ?
+WHERE id = ? AND user_id = ? AND accepted_answer_id IS NULL AND closed = 0
+`
+
+type AcceptAnswerParams struct {
+	AcceptedAnswerID sql.NullInt32
+	UpdatedAt        time.Time
+	ID               int32
+	UserID           int32
+}
+
+func (q *Queries) AcceptAnswer(ctx context.Context, arg AcceptAnswerParams) (int64, error) {
+	result, err := q.exec(ctx, q.acceptAnswerStmt, acceptAnswer,
+		arg.AcceptedAnswerID,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const closeQuestion = `-- name: CloseQuestion :execrows
 UPDATE questions
 SET closed = 1, updated_at = ?
-WHERE id = ? AND user_id = ?
+WHERE id = ? AND user_id = ? AND closed = 0
 `
 
 type CloseQuestionParams struct {
@@ -22,12 +50,15 @@ type CloseQuestionParams struct {
 	UserID    int32
 }
 
-func (q *Queries) CloseQuestion(ctx context.Context, arg CloseQuestionParams) error {
-	_, err := q.exec(ctx, q.closeQuestionStmt, closeQuestion, arg.UpdatedAt, arg.ID, arg.UserID)
-	return err
+func (q *Queries) CloseQuestion(ctx context.Context, arg CloseQuestionParams) (int64, error) {
+	result, err := q.exec(ctx, q.closeQuestionStmt, closeQuestion, arg.UpdatedAt, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const createQuestion = `-- name: CreateQuestion :exec
+const createQuestion = `-- name: CreateQuestion :execlastid
 INSERT INTO questions (title, body, ` + "`" + `priority_level` + "`" + `, user_id, responded_at, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?, ?)
 `
@@ -42,8 +73,8 @@ type CreateQuestionParams struct {
 	UpdatedAt     time.Time
 }
 
-func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams) error {
-	_, err := q.exec(ctx, q.createQuestionStmt, createQuestion,
+func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams) (int64, error) {
+	result, err := q.exec(ctx, q.createQuestionStmt, createQuestion,
 		arg.Title,
 		arg.Body,
 		arg.PriorityLevel,
@@ -52,7 +83,10 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
 }
 
 const deleteQuestion = `-- name: DeleteQuestion :exec
@@ -70,24 +104,182 @@ func (q *Queries) DeleteQuestion(ctx context.Context, arg DeleteQuestionParams)
 	return err
 }
 
+const fullTextSearchQuestions = `-- name: FullTextSearchQuestions :many
+SELECT questions.id, questions.title, questions.body,
+  CAST(
+    MATCH(questions.title) AGAINST (?) * 3 + MATCH(questions.body) AGAINST (?) +
+    COALESCE((SELECT SUM(MATCH(answers.body) AGAINST (?)) FROM answers
+      WHERE answers.question_id = questions.id), 0)
+  AS DOUBLE) AS score,
+  COALESCE((SELECT answers.body FROM answers
+    WHERE answers.question_id = questions.id AND MATCH(answers.body) AGAINST (?)
+    ORDER BY MATCH(answers.body) AGAINST (?) DESC
+    LIMIT 1), '') AS answer_body
+FROM questions
+WHERE MATCH(questions.title) AGAINST (?)
+  OR MATCH(questions.body) AGAINST (?)
+  OR questions.id IN (SELECT answers.question_id FROM answers WHERE MATCH(answers.body) AGAINST (?))
+ORDER BY score DESC, questions.id DESC
+LIMIT ?
+`
+
+type FullTextSearchQuestionsParams struct {
+	Query string
+	Limit int32
+}
+
+type FullTextSearchQuestionsRow struct {
+	ID         int32
+	Title      string
+	Body       string
+	Score      float64
+	AnswerBody string
+}
+
+func (q *Queries) FullTextSearchQuestions(ctx context.Context, arg FullTextSearchQuestionsParams) ([]FullTextSearchQuestionsRow, error) {
+	rows, err := q.query(ctx, q.fullTextSearchQuestionsStmt, fullTextSearchQuestions,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Query,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FullTextSearchQuestionsRow
+	for rows.Next() {
+		var i FullTextSearchQuestionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Body,
+			&i.Score,
+			&i.AnswerBody,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAcceptedAnswerIdForUpdate = `-- name: GetAcceptedAnswerIdForUpdate :one
+SELECT accepted_answer_id FROM questions
+WHERE id = ?
+FOR UPDATE
+`
+
+func (q *Queries) GetAcceptedAnswerIdForUpdate(ctx context.Context, id int32) (sql.NullInt32, error) {
+	row := q.queryRow(ctx, q.getAcceptedAnswerIdForUpdateStmt, getAcceptedAnswerIdForUpdate, id)
+	var accepted_answer_id sql.NullInt32
+	err := row.Scan(&accepted_answer_id)
+	return accepted_answer_id, err
+}
+
+const getAllQuestionsByUserId = `-- name: GetAllQuestionsByUserId :many
+SELECT id, title, body, priority_level, user_id, responded_at, closed, created_at, updated_at, accepted_answer_id FROM questions
+WHERE user_id = ?
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetAllQuestionsByUserId(ctx context.Context, userID int32) ([]Question, error) {
+	rows, err := q.query(ctx, q.getAllQuestionsByUserIdStmt, getAllQuestionsByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Question
+	for rows.Next() {
+		var i Question
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Body,
+			&i.PriorityLevel,
+			&i.UserID,
+			&i.RespondedAt,
+			&i.Closed,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.AcceptedAnswerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllQuestionTexts = `-- name: GetAllQuestionTexts :many
+SELECT id, title, body FROM questions
+`
+
+type GetAllQuestionTextsRow struct {
+	ID    int32
+	Title string
+	Body  string
+}
+
+func (q *Queries) GetAllQuestionTexts(ctx context.Context) ([]GetAllQuestionTextsRow, error) {
+	rows, err := q.query(ctx, q.getAllQuestionTextsStmt, getAllQuestionTexts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllQuestionTextsRow
+	for rows.Next() {
+		var i GetAllQuestionTextsRow
+		if err := rows.Scan(&i.ID, &i.Title, &i.Body); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getQuestionById = `-- name: GetQuestionById :one
-SELECT questions.id, questions.title, questions.body, questions.priority_level, questions.user_id, questions.responded_at, questions.closed, questions.created_at, questions.updated_at, ` + "`" + `name` + "`" + `, email FROM questions
+SELECT questions.id, questions.title, questions.body, questions.priority_level, questions.user_id, questions.responded_at, questions.closed, questions.created_at, questions.updated_at, questions.accepted_answer_id, ` + "`" + `name` + "`" + `, email FROM questions
 INNER JOIN users ON users.id = questions.user_id
 WHERE questions.id = ?
 `
 
 type GetQuestionByIdRow struct {
-	ID            int32
-	Title         string
-	Body          string
-	PriorityLevel int32
-	UserID        int32
-	RespondedAt   time.Time
-	Closed        bool
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Name          string
-	Email         string
+	ID               int32
+	Title            string
+	Body             string
+	PriorityLevel    int32
+	UserID           int32
+	RespondedAt      time.Time
+	Closed           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	AcceptedAnswerID sql.NullInt32
+	Name             string
+	Email            string
 }
 
 func (q *Queries) GetQuestionById(ctx context.Context, id int32) (GetQuestionByIdRow, error) {
@@ -103,6 +295,7 @@ func (q *Queries) GetQuestionById(ctx context.Context, id int32) (GetQuestionByI
 		&i.Closed,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AcceptedAnswerID,
 		&i.Name,
 		&i.Email,
 	)
@@ -110,22 +303,72 @@ func (q *Queries) GetQuestionById(ctx context.Context, id int32) (GetQuestionByI
 }
 
 const getQuestionsByUserId = `-- name: GetQuestionsByUserId :many
-SELECT id, title, body, ` + "`" + `priority_level` + "`" + `, closed, updated_at FROM questions
+SELECT id, title, body, ` + "`" + `priority_level` + "`" + `, closed, responded_at, updated_at FROM questions
 WHERE user_id = ?
-ORDER BY closed ASC, ` + "`" + `priority_level` + "`" + ` DESC, responded_at ASC, updated_at DESC
+  AND (? = 0 OR id IN (
+    SELECT question_tags.question_id FROM question_tags
+    INNER JOIN tags ON tags.id = question_tags.tag_id
+    WHERE tags.` + "`" + `name` + "`" + ` IN (/*SLICE:tags*/?)
+    GROUP BY question_tags.question_id
+    HAVING COUNT(*) = ?))
+  AND (? IS NULL
+    OR closed > ?
+    OR (closed = ? AND (` + "`" + `priority_level` + "`" + ` < ?
+      OR (` + "`" + `priority_level` + "`" + ` = ? AND (responded_at > ?
+        OR (responded_at = ? AND (updated_at < ?
+          OR (updated_at = ? AND id > ?))))))))
+ORDER BY closed ASC, ` + "`" + `priority_level` + "`" + ` DESC, responded_at ASC, updated_at DESC, id ASC
+LIMIT ?
 `
 
+type GetQuestionsByUserIdParams struct {
+	UserID              int32
+	TagCount            interface{}
+	Tags                []string
+	CursorID            sql.NullInt32
+	CursorClosed        sql.NullBool
+	CursorPriorityLevel sql.NullInt32
+	CursorRespondedAt   sql.NullTime
+	CursorUpdatedAt     sql.NullTime
+	Limit               int32
+}
+
 type GetQuestionsByUserIdRow struct {
 	ID            int32
 	Title         string
 	Body          string
 	PriorityLevel int32
 	Closed        bool
+	RespondedAt   time.Time
 	UpdatedAt     time.Time
 }
 
-func (q *Queries) GetQuestionsByUserId(ctx context.Context, userID int32) ([]GetQuestionsByUserIdRow, error) {
-	rows, err := q.query(ctx, q.getQuestionsByUserIdStmt, getQuestionsByUserId, userID)
+func (q *Queries) GetQuestionsByUserId(ctx context.Context, arg GetQuestionsByUserIdParams) ([]GetQuestionsByUserIdRow, error) {
+	query := getQuestionsByUserId
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.TagCount)
+	if len(arg.Tags) > 0 {
+		for _, v := range arg.Tags {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tags*/?", strings.Repeat(",?", len(arg.Tags))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tags*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.TagCount)
+	queryParams = append(queryParams, arg.CursorID)
+	queryParams = append(queryParams, arg.CursorClosed)
+	queryParams = append(queryParams, arg.CursorClosed)
+	queryParams = append(queryParams, arg.CursorPriorityLevel)
+	queryParams = append(queryParams, arg.CursorPriorityLevel)
+	queryParams = append(queryParams, arg.CursorRespondedAt)
+	queryParams = append(queryParams, arg.CursorRespondedAt)
+	queryParams = append(queryParams, arg.CursorUpdatedAt)
+	queryParams = append(queryParams, arg.CursorUpdatedAt)
+	queryParams = append(queryParams, arg.CursorID)
+	queryParams = append(queryParams, arg.Limit)
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +382,7 @@ func (q *Queries) GetQuestionsByUserId(ctx context.Context, userID int32) ([]Get
 			&i.Body,
 			&i.PriorityLevel,
 			&i.Closed,
+			&i.RespondedAt,
 			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -154,6 +398,38 @@ func (q *Queries) GetQuestionsByUserId(ctx context.Context, userID int32) ([]Get
 	return items, nil
 }
 
+const reassignQuestions = `-- name: ReassignQuestions :exec
+UPDATE questions
+SET user_id = ?
+WHERE user_id = ?
+`
+
+type ReassignQuestionsParams struct {
+	NewUserID int32
+	OldUserID int32
+}
+
+func (q *Queries) ReassignQuestions(ctx context.Context, arg ReassignQuestionsParams) error {
+	_, err := q.exec(ctx, q.reassignQuestionsStmt, reassignQuestions, arg.NewUserID, arg.OldUserID)
+	return err
+}
+
+const resetQuestionPriority = `-- name: ResetQuestionPriority :exec
+UPDATE questions
+SET ` + "`" + `priority_level` + "`" + ` = 0, updated_at = ?
+WHERE id = ?
+`
+
+type ResetQuestionPriorityParams struct {
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) ResetQuestionPriority(ctx context.Context, arg ResetQuestionPriorityParams) error {
+	_, err := q.exec(ctx, q.resetQuestionPriorityStmt, resetQuestionPriority, arg.UpdatedAt, arg.ID)
+	return err
+}
+
 const respondToQuestion = `-- name: RespondToQuestion :exec
 UPDATE questions
 SET responded_at = ?, updated_at = ?
@@ -172,22 +448,72 @@ func (q *Queries) RespondToQuestion(ctx context.Context, arg RespondToQuestionPa
 }
 
 const searchQuestions = `-- name: SearchQuestions :many
-SELECT id, title, body, ` + "`" + `priority_level` + "`" + `, closed, updated_at FROM questions
+SELECT id, title, body, ` + "`" + `priority_level` + "`" + `, closed, responded_at, updated_at FROM questions
 WHERE title LIKE ?
-ORDER BY closed ASC, ` + "`" + `priority_level` + "`" + ` DESC, responded_at ASC, updated_at DESC
+  AND (? = 0 OR id IN (
+    SELECT question_tags.question_id FROM question_tags
+    INNER JOIN tags ON tags.id = question_tags.tag_id
+    WHERE tags.` + "`" + `name` + "`" + ` IN (/*SLICE:tags*/?)
+    GROUP BY question_tags.question_id
+    HAVING COUNT(*) = ?))
+  AND (? IS NULL
+    OR closed > ?
+    OR (closed = ? AND (` + "`" + `priority_level` + "`" + ` < ?
+      OR (` + "`" + `priority_level` + "`" + ` = ? AND (responded_at > ?
+        OR (responded_at = ? AND (updated_at < ?
+          OR (updated_at = ? AND id > ?))))))))
+ORDER BY closed ASC, ` + "`" + `priority_level` + "`" + ` DESC, responded_at ASC, updated_at DESC, id ASC
+LIMIT ?
 `
 
+type SearchQuestionsParams struct {
+	Title               string
+	TagCount            interface{}
+	Tags                []string
+	CursorID            sql.NullInt32
+	CursorClosed        sql.NullBool
+	CursorPriorityLevel sql.NullInt32
+	CursorRespondedAt   sql.NullTime
+	CursorUpdatedAt     sql.NullTime
+	Limit               int32
+}
+
 type SearchQuestionsRow struct {
 	ID            int32
 	Title         string
 	Body          string
 	PriorityLevel int32
 	Closed        bool
+	RespondedAt   time.Time
 	UpdatedAt     time.Time
 }
 
-func (q *Queries) SearchQuestions(ctx context.Context, title string) ([]SearchQuestionsRow, error) {
-	rows, err := q.query(ctx, q.searchQuestionsStmt, searchQuestions, title)
+func (q *Queries) SearchQuestions(ctx context.Context, arg SearchQuestionsParams) ([]SearchQuestionsRow, error) {
+	query := searchQuestions
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.Title)
+	queryParams = append(queryParams, arg.TagCount)
+	if len(arg.Tags) > 0 {
+		for _, v := range arg.Tags {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tags*/?", strings.Repeat(",?", len(arg.Tags))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tags*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.TagCount)
+	queryParams = append(queryParams, arg.CursorID)
+	queryParams = append(queryParams, arg.CursorClosed)
+	queryParams = append(queryParams, arg.CursorClosed)
+	queryParams = append(queryParams, arg.CursorPriorityLevel)
+	queryParams = append(queryParams, arg.CursorPriorityLevel)
+	queryParams = append(queryParams, arg.CursorRespondedAt)
+	queryParams = append(queryParams, arg.CursorRespondedAt)
+	queryParams = append(queryParams, arg.CursorUpdatedAt)
+	queryParams = append(queryParams, arg.CursorUpdatedAt)
+	queryParams = append(queryParams, arg.CursorID)
+	queryParams = append(queryParams, arg.Limit)
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -201,6 +527,7 @@ func (q *Queries) SearchQuestions(ctx context.Context, title string) ([]SearchQu
 			&i.Body,
 			&i.PriorityLevel,
 			&i.Closed,
+			&i.RespondedAt,
 			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
@@ -39,6 +40,87 @@ func (q *Queries) CheckIfEmailExists(ctx context.Context, email string) (int64,
 	return count, err
 }
 
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = ?
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
+	_, err := q.exec(ctx, q.deleteUserStmt, deleteUser, id)
+	return err
+}
+
+const disableUserTotp = `-- name: DisableUserTotp :exec
+UPDATE users
+SET totp_secret = NULL, totp_enabled = 0, updated_at = ?
+WHERE id = ?
+`
+
+type DisableUserTotpParams struct {
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) DisableUserTotp(ctx context.Context, arg DisableUserTotpParams) error {
+	_, err := q.exec(ctx, q.disableUserTotpStmt, disableUserTotp, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const enableUserTotp = `-- name: EnableUserTotp :exec
+UPDATE users
+SET totp_enabled = 1, updated_at = ?
+WHERE id = ?
+`
+
+type EnableUserTotpParams struct {
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) EnableUserTotp(ctx context.Context, arg EnableUserTotpParams) error {
+	_, err := q.exec(ctx, q.enableUserTotpStmt, enableUserTotp, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const getUserById = `-- name: GetUserById :one
+SELECT id, name, email, password, points, credits, created_at, updated_at, verified_at, totp_secret, totp_enabled, role, bio, totp_last_counter FROM users
+WHERE id = ?
+`
+
+func (q *Queries) GetUserById(ctx context.Context, id int32) (User, error) {
+	row := q.queryRow(ctx, q.getUserByIdStmt, getUserById, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Password,
+		&i.Points,
+		&i.Credits,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.Role,
+		&i.Bio,
+		&i.TotpLastCounter,
+	)
+	return i, err
+}
+
+const getUserIdByEmail = `-- name: GetUserIdByEmail :one
+SELECT id FROM users
+WHERE email = ?
+`
+
+func (q *Queries) GetUserIdByEmail(ctx context.Context, email string) (int32, error) {
+	row := q.queryRow(ctx, q.getUserIdByEmailStmt, getUserIdByEmail, email)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getUserPointsAndCredits = `-- name: GetUserPointsAndCredits :one
 SELECT points, credits FROM users
 WHERE id = ?
@@ -56,8 +138,53 @@ func (q *Queries) GetUserPointsAndCredits(ctx context.Context, id int32) (GetUse
 	return i, err
 }
 
+const getUserProfile = `-- name: GetUserProfile :one
+SELECT id, ` + "`" + `name` + "`" + `, bio, points, created_at,
+  (SELECT COUNT(*) FROM questions WHERE questions.user_id = users.id) AS question_count,
+  (SELECT COUNT(*) FROM answers WHERE answers.user_id = users.id) AS answer_count
+FROM users
+WHERE id = ?
+`
+
+type GetUserProfileRow struct {
+	ID            int32
+	Name          string
+	Bio           string
+	Points        int32
+	CreatedAt     time.Time
+	QuestionCount int64
+	AnswerCount   int64
+}
+
+func (q *Queries) GetUserProfile(ctx context.Context, id int32) (GetUserProfileRow, error) {
+	row := q.queryRow(ctx, q.getUserProfileStmt, getUserProfile, id)
+	var i GetUserProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Points,
+		&i.CreatedAt,
+		&i.QuestionCount,
+		&i.AnswerCount,
+	)
+	return i, err
+}
+
+const getUserRole = `-- name: GetUserRole :one
+SELECT ` + "`" + `role` + "`" + ` FROM users
+WHERE id = ?
+`
+
+func (q *Queries) GetUserRole(ctx context.Context, id int32) (string, error) {
+	row := q.queryRow(ctx, q.getUserRoleStmt, getUserRole, id)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
 const login = `-- name: Login :one
-SELECT id, name, email, password, points, credits, created_at, updated_at FROM users
+SELECT id, name, email, password, points, credits, created_at, updated_at, verified_at, totp_secret, totp_enabled, role, bio, totp_last_counter FROM users
 WHERE email = ?
 `
 
@@ -73,6 +200,12 @@ func (q *Queries) Login(ctx context.Context, email string) (User, error) {
 		&i.Credits,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VerifiedAt,
+		&i.TotpSecret,
+		&i.TotpEnabled,
+		&i.Role,
+		&i.Bio,
+		&i.TotpLastCounter,
 	)
 	return i, err
 }
@@ -118,6 +251,40 @@ func (q *Queries) RemoveUserCredit(ctx context.Context, arg RemoveUserCreditPara
 	return err
 }
 
+const setUserTotpSecret = `-- name: SetUserTotpSecret :exec
+UPDATE users
+SET totp_secret = ?, updated_at = ?
+WHERE id = ? AND totp_enabled = 0
+`
+
+type SetUserTotpSecretParams struct {
+	TotpSecret sql.NullString
+	UpdatedAt  time.Time
+	ID         int32
+}
+
+func (q *Queries) SetUserTotpSecret(ctx context.Context, arg SetUserTotpSecretParams) error {
+	_, err := q.exec(ctx, q.setUserTotpSecretStmt, setUserTotpSecret, arg.TotpSecret, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET ` + "`" + `password` + "`" + ` = ?, updated_at = ?
+WHERE id = ?
+`
+
+type UpdateUserPasswordParams struct {
+	Password  string
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.exec(ctx, q.updateUserPasswordStmt, updateUserPassword, arg.Password, arg.UpdatedAt, arg.ID)
+	return err
+}
+
 const updateUserPoints = `-- name: UpdateUserPoints :exec
 UPDATE users
 SET points = points + ?, updated_at = ?
@@ -134,3 +301,80 @@ func (q *Queries) UpdateUserPoints(ctx context.Context, arg UpdateUserPointsPara
 	_, err := q.exec(ctx, q.updateUserPointsStmt, updateUserPoints, arg.Points, arg.UpdatedAt, arg.ID)
 	return err
 }
+
+const updateUserProfile = `-- name: UpdateUserProfile :exec
+UPDATE users
+SET ` + "`" + `name` + "`" + ` = ?, bio = ?, updated_at = ?
+WHERE id = ?
+`
+
+type UpdateUserProfileParams struct {
+	Name      string
+	Bio       string
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error {
+	_, err := q.exec(ctx, q.updateUserProfileStmt, updateUserProfile,
+		arg.Name,
+		arg.Bio,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :exec
+UPDATE users
+SET ` + "`" + `role` + "`" + ` = ?, updated_at = ?
+WHERE id = ?
+`
+
+type UpdateUserRoleParams struct {
+	Role      string
+	UpdatedAt time.Time
+	ID        int32
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error {
+	_, err := q.exec(ctx, q.updateUserRoleStmt, updateUserRole, arg.Role, arg.UpdatedAt, arg.ID)
+	return err
+}
+
+const useUserTotpCounter = `-- name: UseUserTotpCounter :execrows
+UPDATE users
+SET totp_last_counter = ?
+WHERE id = ? AND totp_last_counter < ?
+`
+
+type UseUserTotpCounterParams struct {
+	TotpLastCounter   int64
+	ID                int32
+	TotpLastCounter_2 int64
+}
+
+func (q *Queries) UseUserTotpCounter(ctx context.Context, arg UseUserTotpCounterParams) (int64, error) {
+	result, err := q.exec(ctx, q.useUserTotpCounterStmt, useUserTotpCounter, arg.TotpLastCounter, arg.ID, arg.TotpLastCounter_2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :exec
+UPDATE users
+SET verified_at = ?, updated_at = ?
+WHERE id = ? AND verified_at IS NULL
+`
+
+type VerifyUserEmailParams struct {
+	VerifiedAt sql.NullTime
+	UpdatedAt  time.Time
+	ID         int32
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) error {
+	_, err := q.exec(ctx, q.verifyUserEmailStmt, verifyUserEmail, arg.VerifiedAt, arg.UpdatedAt, arg.ID)
+	return err
+}
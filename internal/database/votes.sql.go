@@ -72,6 +72,58 @@ func (q *Queries) Downvote(ctx context.Context, arg DownvoteParams) error {
 	return err
 }
 
+const getVotesByUserId = `-- name: GetVotesByUserId :many
+SELECT id, val, answer_id, user_id, created_at, updated_at FROM votes
+WHERE user_id = ?
+ORDER BY created_at ASC
+`
+
+func (q *Queries) GetVotesByUserId(ctx context.Context, userID int32) ([]Vote, error) {
+	rows, err := q.query(ctx, q.getVotesByUserIdStmt, getVotesByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Vote
+	for rows.Next() {
+		var i Vote
+		if err := rows.Scan(
+			&i.ID,
+			&i.Val,
+			&i.AnswerID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignVotes = `-- name: ReassignVotes :exec
+UPDATE votes
+SET user_id = ?
+WHERE user_id = ?
+`
+
+type ReassignVotesParams struct {
+	NewUserID int32
+	OldUserID int32
+}
+
+func (q *Queries) ReassignVotes(ctx context.Context, arg ReassignVotesParams) error {
+	_, err := q.exec(ctx, q.reassignVotesStmt, reassignVotes, arg.NewUserID, arg.OldUserID)
+	return err
+}
+
 const upvote = `-- name: Upvote :exec
 UPDATE votes
 SET val = 1, updated_at = ?
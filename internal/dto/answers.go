@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+type Answer struct {
+	ID         int32     `json:"id"`
+	Body       string    `json:"body"`
+	Votes      int32     `json:"votes"`
+	QuestionID int32     `json:"question_id"`
+	UserID     int32     `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type AnswerWithAuthor struct {
+	Answer
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Accepted bool   `json:"accepted"`
+}
+
+func NewAnswer(row database.Answer) Answer {
+	return Answer{
+		ID:         row.ID,
+		Body:       row.Body,
+		Votes:      row.Votes,
+		QuestionID: row.QuestionID,
+		UserID:     row.UserID,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
+
+func NewAnswers(rows []database.Answer) []Answer {
+	answers := make([]Answer, 0, len(rows))
+	for _, row := range rows {
+		answers = append(answers, NewAnswer(row))
+	}
+	return answers
+}
+
+func NewAnswersWithAuthor(rows []database.GetAnswersByQuestionIdRow, viewer Viewer) []AnswerWithAuthor {
+	answers := make([]AnswerWithAuthor, 0, len(rows))
+	for _, row := range rows {
+		answer := AnswerWithAuthor{
+			Answer: Answer{
+				ID:         row.ID,
+				Body:       row.Body,
+				Votes:      row.Votes,
+				QuestionID: row.QuestionID,
+				UserID:     row.UserID,
+				CreatedAt:  row.CreatedAt,
+				UpdatedAt:  row.UpdatedAt,
+			},
+			Name:     row.Name,
+			Accepted: row.AcceptedAnswerID.Valid && row.AcceptedAnswerID.Int32 == row.ID,
+		}
+		if viewer.CanSee(authorEmailVisibility, row.UserID) {
+			answer.Email = row.Email
+		}
+		answers = append(answers, answer)
+	}
+	return answers
+}
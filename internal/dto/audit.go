@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+type AuditEvent struct {
+	ID        int64           `json:"id"`
+	ActorID   *int32          `json:"actor_id"`
+	Action    string          `json:"action"`
+	IPAddress string          `json:"ip_address"`
+	UserAgent string          `json:"user_agent"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func NewAuditEvents(rows []database.AuditEvent) []AuditEvent {
+	events := make([]AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		event := AuditEvent{
+			ID:        row.ID,
+			Action:    row.Action,
+			IPAddress: row.IpAddress,
+			UserAgent: row.UserAgent,
+			Payload:   row.Payload,
+			CreatedAt: row.CreatedAt,
+		}
+		if row.ActorID.Valid {
+			actorId := row.ActorID.Int32
+			event.ActorID = &actorId
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+type Bounty struct {
+	ID              int32      `json:"id"`
+	QuestionID      int32      `json:"question_id"`
+	Amount          int32      `json:"amount"`
+	Status          string     `json:"status"`
+	AwardedAnswerID *int32     `json:"awarded_answer_id"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	SettledAt       *time.Time `json:"settled_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func NewBounties(rows []database.Bounty) []Bounty {
+	bounties := make([]Bounty, 0, len(rows))
+	for _, row := range rows {
+		bounty := Bounty{
+			ID:              row.ID,
+			QuestionID:      row.QuestionID,
+			Amount:          row.Amount,
+			Status:          row.Status,
+			AwardedAnswerID: nullInt32Pointer(row.AwardedAnswerID),
+			ExpiresAt:       row.ExpiresAt,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+		}
+		if row.SettledAt.Valid {
+			bounty.SettledAt = &row.SettledAt.Time
+		}
+		bounties = append(bounties, bounty)
+	}
+	return bounties
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+type ModerationAction struct {
+	ID           int32     `json:"id"`
+	ModeratorID  int32     `json:"moderator_id"`
+	Action       string    `json:"action"`
+	TargetType   string    `json:"target_type"`
+	TargetID     int32     `json:"target_id"`
+	TargetUserID int32     `json:"target_user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	Name         string    `json:"name"`
+}
+
+func NewModerationActions(rows []database.GetModerationActionsRow) []ModerationAction {
+	actions := make([]ModerationAction, 0, len(rows))
+	for _, row := range rows {
+		actions = append(actions, ModerationAction{
+			ID:           row.ID,
+			ModeratorID:  row.ModeratorID,
+			Action:       row.Action,
+			TargetType:   row.TargetType,
+			TargetID:     row.TargetID,
+			TargetUserID: row.TargetUserID,
+			CreatedAt:    row.CreatedAt,
+			Name:         row.Name,
+		})
+	}
+	return actions
+}
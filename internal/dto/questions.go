@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// Visibility of the author's email on questions and answers
+const authorEmailVisibility = Owner
+
+type QuestionSummary struct {
+	ID            int32     `json:"id"`
+	Title         string    `json:"title"`
+	Body          string    `json:"body"`
+	PriorityLevel int32     `json:"priority_level"`
+	Closed        bool      `json:"closed"`
+	RespondedAt   time.Time `json:"responded_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Tags          []string  `json:"tags"`
+}
+
+type Question struct {
+	ID               int32     `json:"id"`
+	Title            string    `json:"title"`
+	Body             string    `json:"body"`
+	PriorityLevel    int32     `json:"priority_level"`
+	UserID           int32     `json:"user_id"`
+	RespondedAt      time.Time `json:"responded_at"`
+	Closed           bool      `json:"closed"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	AcceptedAnswerID *int32    `json:"accepted_answer_id"`
+	Name             string    `json:"name"`
+	Email            string    `json:"email,omitempty"`
+	Tags             []string  `json:"tags"`
+}
+
+// QuestionRecord is a question without author details,
+// used where the author is already known.
+type QuestionRecord struct {
+	ID               int32     `json:"id"`
+	Title            string    `json:"title"`
+	Body             string    `json:"body"`
+	PriorityLevel    int32     `json:"priority_level"`
+	UserID           int32     `json:"user_id"`
+	RespondedAt      time.Time `json:"responded_at"`
+	Closed           bool      `json:"closed"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	AcceptedAnswerID *int32    `json:"accepted_answer_id"`
+}
+
+// NewQuestionSummaries converts the rows of a question listing.
+// tags maps the question IDs to their tags.
+func NewQuestionSummaries[T database.SearchQuestionsRow | database.GetQuestionsByUserIdRow](rows []T, tags map[int32][]string) []QuestionSummary {
+	questions := make([]QuestionSummary, 0, len(rows))
+	for _, row := range rows {
+		question := database.SearchQuestionsRow(row)
+		questions = append(questions, QuestionSummary{
+			ID:            question.ID,
+			Title:         question.Title,
+			Body:          question.Body,
+			PriorityLevel: question.PriorityLevel,
+			Closed:        question.Closed,
+			RespondedAt:   question.RespondedAt,
+			UpdatedAt:     question.UpdatedAt,
+			Tags:          tagsOrEmpty(tags[question.ID]),
+		})
+	}
+	return questions
+}
+
+func NewQuestion(row database.GetQuestionByIdRow, tags []string, viewer Viewer) Question {
+	question := Question{
+		ID:               row.ID,
+		Title:            row.Title,
+		Body:             row.Body,
+		PriorityLevel:    row.PriorityLevel,
+		UserID:           row.UserID,
+		RespondedAt:      row.RespondedAt,
+		Closed:           row.Closed,
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+		AcceptedAnswerID: nullInt32Pointer(row.AcceptedAnswerID),
+		Name:             row.Name,
+		Tags:             tagsOrEmpty(tags),
+	}
+	if viewer.CanSee(authorEmailVisibility, row.UserID) {
+		question.Email = row.Email
+	}
+	return question
+}
+
+func NewQuestionRecords(rows []database.Question) []QuestionRecord {
+	questions := make([]QuestionRecord, 0, len(rows))
+	for _, row := range rows {
+		questions = append(questions, QuestionRecord{
+			ID:               row.ID,
+			Title:            row.Title,
+			Body:             row.Body,
+			PriorityLevel:    row.PriorityLevel,
+			UserID:           row.UserID,
+			RespondedAt:      row.RespondedAt,
+			Closed:           row.Closed,
+			CreatedAt:        row.CreatedAt,
+			UpdatedAt:        row.UpdatedAt,
+			AcceptedAnswerID: nullInt32Pointer(row.AcceptedAnswerID),
+		})
+	}
+	return questions
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func nullInt32Pointer(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+type Revision struct {
+	ID         int32     `json:"id"`
+	Title      *string   `json:"title,omitempty"`
+	Body       string    `json:"body"`
+	EditorID   int32     `json:"editor_id"`
+	EditorName string    `json:"editor_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// RevisionRecord is a revision without editor details,
+// used where the editor is already known.
+type RevisionRecord struct {
+	ID         int32     `json:"id"`
+	QuestionID *int32    `json:"question_id"`
+	AnswerID   *int32    `json:"answer_id"`
+	Title      *string   `json:"title,omitempty"`
+	Body       string    `json:"body"`
+	EditorID   int32     `json:"editor_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+func NewRevisions[T database.GetRevisionsByQuestionIdRow | database.GetRevisionsByAnswerIdRow](rows []T) []Revision {
+	revisions := make([]Revision, 0, len(rows))
+	for _, row := range rows {
+		revision := database.GetRevisionsByQuestionIdRow(row)
+		newRevision := Revision{
+			ID:         revision.ID,
+			Body:       revision.Body,
+			EditorID:   revision.EditorID,
+			EditorName: revision.Name,
+			CreatedAt:  revision.CreatedAt,
+		}
+		if revision.Title.Valid {
+			newRevision.Title = &revision.Title.String
+		}
+		revisions = append(revisions, newRevision)
+	}
+	return revisions
+}
+
+func NewRevisionRecords(rows []database.Revision) []RevisionRecord {
+	revisions := make([]RevisionRecord, 0, len(rows))
+	for _, row := range rows {
+		revision := RevisionRecord{
+			ID:         row.ID,
+			QuestionID: nullInt32Pointer(row.QuestionID),
+			AnswerID:   nullInt32Pointer(row.AnswerID),
+			Body:       row.Body,
+			EditorID:   row.EditorID,
+			CreatedAt:  row.CreatedAt,
+		}
+		if row.Title.Valid {
+			revision.Title = &row.Title.String
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+func NewDiff(lines []utils.DiffLine) []DiffLine {
+	diff := make([]DiffLine, 0, len(lines))
+	for _, line := range lines {
+		diff = append(diff, DiffLine{
+			Op:   line.Op,
+			Text: line.Text,
+		})
+	}
+	return diff
+}
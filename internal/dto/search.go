@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/vuezy/go-ask-and-answer/internal/search"
+
+type SearchResult struct {
+	QuestionID int32   `json:"id"`
+	Title      string  `json:"title"`
+	Snippet    string  `json:"snippet"`
+	Score      float64 `json:"score"`
+}
+
+func NewSearchResults(results []search.Result) []SearchResult {
+	searchResults := make([]SearchResult, 0, len(results))
+	for _, result := range results {
+		searchResults = append(searchResults, SearchResult{
+			QuestionID: result.QuestionID,
+			Title:      result.Title,
+			Snippet:    result.Snippet,
+			Score:      result.Score,
+		})
+	}
+	return searchResults
+}
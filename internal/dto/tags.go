@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/vuezy/go-ask-and-answer/internal/database"
+
+type Tag struct {
+	Name          string `json:"name"`
+	QuestionCount int64  `json:"question_count"`
+}
+
+func NewTags(rows []database.GetTagsRow) []Tag {
+	tags := make([]Tag, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, Tag{
+			Name:          row.Name,
+			QuestionCount: row.QuestionCount,
+		})
+	}
+	return tags
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"context"
+
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// Visibility is the policy of a response field, deciding who may see it.
+type Visibility int
+
+const (
+	// Public fields are visible to everyone.
+	Public Visibility = iota
+	// Owner fields are visible to the owner of the resource and to moderators.
+	Owner
+	// Moderator fields are only visible to moderators.
+	Moderator
+)
+
+// Viewer is the user a response is built for.
+type Viewer struct {
+	UserID int32
+	Role   string
+}
+
+func ViewerFromContext(ctx context.Context) Viewer {
+	return Viewer{
+		UserID: utils.GetTokenSubject(ctx),
+		Role:   utils.GetTokenRole(ctx),
+	}
+}
+
+// CanSee reports whether the viewer may see a field with the given visibility
+// on a resource owned by ownerId.
+func (v Viewer) CanSee(visibility Visibility, ownerId int32) bool {
+	switch visibility {
+	case Public:
+		return true
+	case Owner:
+		return (v.UserID != 0 && v.UserID == ownerId) || utils.IsModerator(v.Role)
+	case Moderator:
+		return utils.IsModerator(v.Role)
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+type Vote struct {
+	ID        int32     `json:"id"`
+	Val       int32     `json:"val"`
+	AnswerID  int32     `json:"answer_id"`
+	UserID    int32     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewVotes(rows []database.Vote) []Vote {
+	votes := make([]Vote, 0, len(rows))
+	for _, row := range rows {
+		votes = append(votes, Vote{
+			ID:        row.ID,
+			Val:       row.Val,
+			AnswerID:  row.AnswerID,
+			UserID:    row.UserID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+	return votes
+}
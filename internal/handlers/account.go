@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/bounties"
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// ExportMe returns an archive of all the data stored about the user.
+func ExportMe(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	questions, err := db.GetAllQuestionsByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetAllQuestionsByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	answers, err := db.GetAnswersByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetAnswersByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	votes, err := db.GetVotesByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetVotesByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	sessions, err := db.GetActiveTokensBySub(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetActiveTokensBySub method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	apiKeys, err := db.GetApiKeysByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetApiKeysByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	revisions, err := db.GetRevisionsByEditorId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetRevisionsByEditorId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	bountyRows, err := db.GetBountiesByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetBountiesByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	auditEvents, err := db.GetAuditEventsByUserId(ctx, sql.NullInt32{Int32: userId, Valid: true})
+	if err != nil {
+		log.Println("Error from db.GetAuditEventsByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	moderationActions, err := db.GetModerationActionsByTargetUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetModerationActionsByTargetUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	sessionsExport := make([]map[string]any, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsExport = append(sessionsExport, map[string]any{
+			"id":           session.ID,
+			"device_name":  session.DeviceName,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IpAddress,
+			"created_at":   session.CreatedAt,
+			"last_seen_at": session.LastSeenAt,
+		})
+	}
+
+	apiKeysExport := make([]map[string]any, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeysExport = append(apiKeysExport, map[string]any{
+			"id":         apiKey.ID,
+			"name":       apiKey.Name,
+			"prefix":     apiKey.KeyPrefix,
+			"scopes":     utils.SplitScopes(apiKey.Scopes),
+			"created_at": apiKey.CreatedAt,
+		})
+	}
+
+	// Credit changes of the user can be made by other users, e.g. by voting.
+	// Who they are and where they connected from is not the user's data.
+	auditEventsExport := dto.NewAuditEvents(auditEvents)
+	for i, event := range auditEventsExport {
+		if event.ActorID == nil || *event.ActorID != userId {
+			auditEventsExport[i].ActorID = nil
+			auditEventsExport[i].IPAddress = ""
+			auditEventsExport[i].UserAgent = ""
+		}
+	}
+
+	// Only moderators can see who moderated a user
+	moderationActionsExport := make([]map[string]any, 0, len(moderationActions))
+	for _, action := range moderationActions {
+		moderationActionsExport = append(moderationActionsExport, map[string]any{
+			"id":          action.ID,
+			"action":      action.Action,
+			"target_type": action.TargetType,
+			"target_id":   action.TargetID,
+			"created_at":  action.CreatedAt,
+		})
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="ask-and-answer-export.json"`)
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":               "success",
+		"exported_at":        time.Now(),
+		"user":               userToMap(user),
+		"questions":          dto.NewQuestionRecords(questions),
+		"answers":            dto.NewAnswers(answers),
+		"votes":              dto.NewVotes(votes),
+		"sessions":           sessionsExport,
+		"api_keys":           apiKeysExport,
+		"revisions":          dto.NewRevisionRecords(revisions),
+		"bounties":           dto.NewBounties(bountyRows),
+		"audit_events":       auditEventsExport,
+		"moderation_actions": moderationActionsExport,
+	})
+}
+
+// DeleteMe deletes the account of the user. Questions, answers and votes are kept
+// and reassigned to the deleted user placeholder, so answers stay useful and vote
+// totals do not change. Everything else, including sessions and API keys,
+// is removed together with the user row.
+func DeleteMe(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.DeleteAccountPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	err = utils.CheckPasswordHash(data.Password, user.Password)
+	if err != nil {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"password": "Password is incorrect",
+			},
+		})
+		return
+	}
+
+	deletedUserId, err := db.GetUserIdByEmail(ctx, utils.DELETED_USER_EMAIL)
+	if err != nil {
+		log.Println("Error from db.GetUserIdByEmail method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	if user.TotpEnabled {
+		valid, err := checkTwoFactorCode(ctx, qtx, user, data.Code)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+		if !valid {
+			tx.Rollback()
+			utils.RespondWithJSON(w, 400, map[string]any{
+				"type": "validation_error",
+				"msg": map[string]any{
+					"code": "Invalid code",
+				},
+			})
+			return
+		}
+	}
+
+	err = bounties.SettleUserBounties(ctx, qtx, r, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.ReassignQuestions(ctx, database.ReassignQuestionsParams{
+		NewUserID: deletedUserId,
+		OldUserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ReassignQuestions method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.ReassignAnswers(ctx, database.ReassignAnswersParams{
+		NewUserID: deletedUserId,
+		OldUserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ReassignAnswers method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.ReassignVotes(ctx, database.ReassignVotesParams{
+		NewUserID: deletedUserId,
+		OldUserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ReassignVotes method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.ReassignModerationActions(ctx, database.ReassignModerationActionsParams{
+		NewUserID: deletedUserId,
+		OldUserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ReassignModerationActions method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.ReassignRevisions(ctx, database.ReassignRevisionsParams{
+		NewUserID: deletedUserId,
+		OldUserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.ReassignRevisions method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// The audit events are kept, but no longer point to the user
+	err = qtx.PseudonymizeAuditEvents(ctx, database.PseudonymizeAuditEventsParams{
+		NewActorID: sql.NullInt32{Int32: deletedUserId, Valid: true},
+		OldActorID: sql.NullInt32{Int32: userId, Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.PseudonymizeAuditEvents method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.RemoveEmailFromAuditEvents(ctx, strings.ToLower(user.Email))
+	if err != nil {
+		log.Println("Error from qtx.RemoveEmailFromAuditEvents method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// Sessions, refresh tokens, API keys and the other personal data
+	// are deleted by the foreign key cascade
+	err = qtx.DeleteUser(ctx, userId)
+	if err != nil {
+		log.Println("Error from qtx.DeleteUser method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(userId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "Your account has been deleted",
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
 	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
@@ -34,7 +35,7 @@ func GetAnswersByQuestionId(w http.ResponseWriter, r *http.Request) {
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type":    "success",
-		"answers": utils.ConvertStructToMap(answers),
+		"answers": dto.NewAnswersWithAuthor(answers, dto.ViewerFromContext(ctx)),
 	})
 }
 
@@ -61,7 +62,7 @@ func GetAnswersByUserId(w http.ResponseWriter, r *http.Request) {
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type":    "success",
-		"answers": utils.ConvertStructToMap(answers),
+		"answers": dto.NewAnswers(answers),
 	})
 }
 
@@ -81,7 +82,7 @@ func GetAnswerById(w http.ResponseWriter, r *http.Request) {
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type":   "success",
-		"answer": utils.ConvertStructToMap(answer),
+		"answer": dto.NewAnswer(answer),
 	})
 }
 
@@ -119,7 +120,7 @@ func CreateAnswer(w http.ResponseWriter, r *http.Request) {
 	}
 	qtx := db.WithTx(tx)
 
-	err = qtx.CreateAnswer(ctx, database.CreateAnswerParams{
+	answerId, err := qtx.CreateAnswer(ctx, database.CreateAnswerParams{
 		Body:       answer.Body,
 		QuestionID: answer.QuestionID,
 		UserID:     userId,
@@ -133,6 +134,13 @@ func CreateAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = createAnswerRevision(ctx, qtx, int32(answerId), answer.Body, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	err = qtx.RespondToQuestion(ctx, database.RespondToQuestionParams{
 		ID:          answer.QuestionID,
 		RespondedAt: time.Now(),
@@ -151,6 +159,7 @@ func CreateAnswer(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith500Error(w)
 		return
 	}
+	updateSearchIndex(ctx, answer.QuestionID)
 
 	utils.RespondWithJSON(w, 201, map[string]any{
 		"type": "success",
@@ -176,7 +185,8 @@ func UpdateAnswer(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith404Error(w)
 		return
 	}
-	if answer.UserID != userId {
+	allowed, moderating := checkContentPermission(ctx, userId, answer.UserID)
+	if !allowed {
 		utils.RespondWith403Error(w)
 		return
 	}
@@ -215,7 +225,7 @@ func UpdateAnswer(w http.ResponseWriter, r *http.Request) {
 
 	err = qtx.UpdateAnswer(ctx, database.UpdateAnswerParams{
 		ID:        answerId,
-		UserID:    userId,
+		UserID:    answer.UserID,
 		Body:      answerPayload.Body,
 		UpdatedAt: time.Now(),
 	})
@@ -226,6 +236,15 @@ func UpdateAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if answerPayload.Body != answer.Body {
+		err = createAnswerRevision(ctx, qtx, answerId, answerPayload.Body, userId)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
 	err = qtx.RespondToQuestion(ctx, database.RespondToQuestionParams{
 		ID:          answer.QuestionID,
 		RespondedAt: time.Now(),
@@ -238,12 +257,22 @@ func UpdateAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "edit", "answer", answerId, answer.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Println("Error commiting the transaction.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	updateSearchIndex(ctx, answer.QuestionID)
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type": "success",
@@ -269,7 +298,8 @@ func DeleteAnswer(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith404Error(w)
 		return
 	}
-	if answer.UserID != userId {
+	allowed, moderating := checkContentPermission(ctx, userId, answer.UserID)
+	if !allowed {
 		utils.RespondWith403Error(w)
 		return
 	}
@@ -290,15 +320,59 @@ func DeleteAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = db.DeleteAnswer(ctx, database.DeleteAnswerParams{
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	// The question is locked, so the answer cannot be accepted while it is deleted
+	acceptedAnswerId, err := qtx.GetAcceptedAnswerIdForUpdate(ctx, answer.QuestionID)
+	if err != nil {
+		log.Println("Error from qtx.GetAcceptedAnswerIdForUpdate method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if acceptedAnswerId.Valid && acceptedAnswerId.Int32 == answerId {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot delete the answer because it has been accepted",
+		})
+		return
+	}
+
+	err = qtx.DeleteAnswer(ctx, database.DeleteAnswerParams{
 		ID:     answerId,
-		UserID: userId,
+		UserID: answer.UserID,
 	})
 	if err != nil {
-		log.Println("Error from db.DeleteAnswer method.", err)
+		log.Println("Error from qtx.DeleteAnswer method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "delete", "answer", answerId, answer.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	updateSearchIndex(ctx, answer.QuestionID)
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type": "success",
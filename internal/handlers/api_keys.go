@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const maxAPIKeysPerUser = 10
+
+func GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	apiKeys, err := db.GetApiKeysByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetApiKeysByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	response := make([]map[string]any, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		var lastUsedAt any
+		if apiKey.LastUsedAt.Valid {
+			lastUsedAt = apiKey.LastUsedAt.Time
+		}
+
+		response = append(response, map[string]any{
+			"id":           apiKey.ID,
+			"name":         apiKey.Name,
+			"prefix":       apiKey.KeyPrefix,
+			"scopes":       utils.SplitScopes(apiKey.Scopes),
+			"last_used_at": lastUsedAt,
+			"created_at":   apiKey.CreatedAt,
+		})
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":     "success",
+		"api_keys": response,
+	})
+}
+
+func CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.APIKeyPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	count, err := db.CountApiKeysByUserId(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.CountApiKeysByUserId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count >= maxAPIKeysPerUser {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "You cannot have more than 10 API keys",
+		})
+		return
+	}
+
+	key, prefix, keyHash, err := utils.GenerateAPIKey()
+	if err != nil {
+		log.Println("Error generating an API key.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = db.CreateApiKey(ctx, database.CreateApiKeyParams{
+		UserID:    userId,
+		Name:      data.Name,
+		KeyPrefix: prefix,
+		KeyHash:   keyHash,
+		Scopes:    strings.Join(data.Scopes, ","),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from db.CreateApiKey method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 201, map[string]any{
+		"type":    "success",
+		"msg":     "The API key has been created. Store it in a safe place, it will not be shown again",
+		"api_key": key,
+	})
+}
+
+func DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	apiKeyId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	count, err := db.DeleteApiKey(ctx, database.DeleteApiKeyParams{
+		ID:     apiKeyId,
+		UserID: userId,
+	})
+	if err != nil {
+		log.Println("Error from db.DeleteApiKey method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The API key has been revoked",
+	})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const defaultAuditEventsLimit = 50
+const maxAuditEventsLimit = 100
+
+// GetAuditEvents returns the audit events from the newest to the oldest.
+// The events can be filtered by actor_id, action and a time range (from, to in RFC 3339).
+// The next page is requested by passing the returned next_cursor as cursor.
+func GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	params := database.GetAuditEventsParams{Limit: defaultAuditEventsLimit}
+	errMsg := map[string]any{}
+
+	if actorIdStr := query.Get("actor_id"); actorIdStr != "" {
+		actorId, err := strconv.ParseInt(actorIdStr, 10, 32)
+		if err != nil {
+			errMsg["actor_id"] = "Actor ID must be a number"
+		}
+		params.ActorID = sql.NullInt32{Int32: int32(actorId), Valid: true}
+	}
+	if action := query.Get("action"); action != "" {
+		params.Action = sql.NullString{String: action, Valid: true}
+	}
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			errMsg["from"] = "From must be a time in RFC 3339 format"
+		}
+		params.FromTime = sql.NullTime{Time: from, Valid: true}
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			errMsg["to"] = "To must be a time in RFC 3339 format"
+		}
+		params.ToTime = sql.NullTime{Time: to, Valid: true}
+	}
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		cursor, err := strconv.ParseInt(cursorStr, 10, 64)
+		if err != nil {
+			errMsg["cursor"] = "The cursor is invalid"
+		}
+		params.Cursor = sql.NullInt64{Int64: cursor, Valid: true}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxAuditEventsLimit {
+			errMsg["limit"] = "Limit must be between 1 and 100"
+		}
+		params.Limit = int32(limit)
+	}
+
+	if len(errMsg) > 0 {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg":  errMsg,
+		})
+		return
+	}
+
+	events, err := db.GetAuditEvents(ctx, params)
+	if err != nil {
+		log.Println("Error from db.GetAuditEvents method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	var nextCursor any
+	if len(events) == int(params.Limit) {
+		nextCursor = events[len(events)-1].ID
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":        "success",
+		"events":      dto.NewAuditEvents(events),
+		"next_cursor": nextCursor,
+	})
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"database/sql"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/vuezy/go-ask-and-answer/internal/database"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
@@ -36,21 +38,86 @@ func RefreshTokens(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jti, accessTokenStr, refreshTokenStr, err := utils.IssueJWT(refreshTokenSub)
+	sessionId, err := db.GetActiveTokenId(ctx, database.GetActiveTokenIdParams{
+		Sub: refreshTokenSub,
+		Jti: refreshToken.JwtID(),
+	})
 	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetActiveTokenId method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.AskToReauthenticate(w)
+		return
+	}
+
+	// The role is read again so that role changes apply from the next refresh
+	role, err := db.GetUserRole(ctx, refreshTokenSub)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserRole method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.AskToReauthenticate(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	qtx := db.WithTx(tx)
 
-	err = db.SetActiveToken(ctx, database.SetActiveTokenParams{
-		Sub: refreshTokenSub,
-		Jti: jti,
+	count, err := qtx.UseRefreshToken(ctx, database.UseRefreshTokenParams{
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		Sub:    refreshTokenSub,
+		Jti:    refreshToken.JwtID(),
 	})
 	if err != nil {
-		log.Println("Error from db.SetActiveToken method.", err)
+		log.Println("Error from qtx.UseRefreshToken method.", err)
+		tx.Rollback()
 		utils.RespondWith500Error(w)
 		return
 	}
+	if count == 0 {
+		// Another request has rotated this refresh token in the meantime
+		tx.Rollback()
+		err = utils.RevokeReusedRefreshToken(r, refreshTokenSub, refreshToken.JwtID())
+		if err != nil {
+			log.Println("Error revoking the refresh token family.", err)
+		}
+		utils.AskToReauthenticate(w)
+		return
+	}
+
+	accessTokenStr, refreshTokenStr, err = rotateSession(ctx, qtx, r, refreshTokenSub, role, sessionId, refreshToken.JwtID())
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = utils.RecordAuditEvent(ctx, qtx, r, refreshTokenSub, utils.AUDIT_TOKEN_REFRESH, map[string]any{
+		"session_id": sessionId,
+	})
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(refreshTokenSub)
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type":          "success",
@@ -58,3 +125,60 @@ func RefreshTokens(w http.ResponseWriter, r *http.Request) {
 		"refresh_token": refreshTokenStr,
 	})
 }
+
+func Logout(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	_, token, _ := utils.JWTAuthFromContext(ctx)
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	err := db.DeleteActiveTokenByJti(ctx, database.DeleteActiveTokenByJtiParams{
+		Sub: userId,
+		Jti: token.JwtID(),
+	})
+	if err != nil {
+		log.Println("Error from db.DeleteActiveTokenByJti method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(userId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "You have been logged out",
+	})
+}
+
+func LogoutAll(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	err := db.DeleteActiveTokensBySub(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.DeleteActiveTokensBySub method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(userId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "You have been logged out from all devices",
+	})
+}
+
+func GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"keys": utils.GetPublicKeys(),
+	})
+}
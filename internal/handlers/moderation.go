@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const moderationActionsLimit = 100
+
+// checkContentPermission reports whether the user may modify content owned by ownerId.
+// Moderators may also modify content of other users, which is reported as moderating
+// so the action can be recorded.
+func checkContentPermission(ctx context.Context, userId int32, ownerId int32) (allowed bool, moderating bool) {
+	if ownerId == userId {
+		return true, false
+	}
+	if utils.IsModerator(utils.GetTokenRole(ctx)) {
+		return true, true
+	}
+	return false, false
+}
+
+func recordModerationAction(
+	ctx context.Context,
+	q *database.Queries,
+	moderatorId int32,
+	action string,
+	targetType string,
+	targetId int32,
+	targetUserId int32,
+) error {
+	err := q.CreateModerationAction(ctx, database.CreateModerationActionParams{
+		ModeratorID:  moderatorId,
+		Action:       action,
+		TargetType:   targetType,
+		TargetID:     targetId,
+		TargetUserID: targetUserId,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateModerationAction method.", err)
+	}
+	return err
+}
+
+func GetModerationActions(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+
+	actions, err := db.GetModerationActions(ctx, moderationActionsLimit)
+	if err != nil {
+		log.Println("Error from db.GetModerationActions method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if len(actions) == 0 {
+		utils.RespondWithJSON(w, 200, map[string]any{
+			"type":    "success",
+			"actions": []any{},
+		})
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":    "success",
+		"actions": dto.NewModerationActions(actions),
+	})
+}
+
+func UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	targetUserId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.RolePayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// Prevents the last admin from locking everyone out of the admin routes
+	if targetUserId == userId {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "You cannot change your own role",
+		})
+		return
+	}
+
+	oldRole, err := db.GetUserRole(ctx, targetUserId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserRole method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.UpdateUserRole(ctx, database.UpdateUserRoleParams{
+		Role:      data.Role,
+		UpdatedAt: time.Now(),
+		ID:        targetUserId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateUserRole method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// The role is carried in the tokens, so the user has to log in again
+	// for the new role to take effect
+	err = qtx.DeleteActiveTokensBySub(ctx, targetUserId)
+	if err != nil {
+		log.Println("Error from qtx.DeleteActiveTokensBySub method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = recordModerationAction(ctx, qtx, userId, "set_role_"+data.Role, "user", targetUserId, targetUserId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = utils.RecordAuditEvent(ctx, qtx, r, userId, utils.AUDIT_ROLE_CHANGE, map[string]any{
+		"user_id":  targetUserId,
+		"old_role": oldRole,
+		"new_role": data.Role,
+	})
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(targetUserId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The role has been updated",
+	})
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/mailer"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const passwordResetTokenLifetime = time.Hour
+
+func ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.ForgotPasswordPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// The response is the same whether or not the email is registered,
+	// so this endpoint cannot be used to find out who has an account
+	response := map[string]any{
+		"type": "success",
+		"msg":  "If the email is registered, a password reset code has been sent to it",
+	}
+
+	user, err := db.Login(ctx, data.Email)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.Login method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWithJSON(w, 200, response)
+		return
+	}
+
+	token, tokenHash, err := utils.GenerateToken()
+	if err != nil {
+		log.Println("Error generating a password reset token.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = db.CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(passwordResetTokenLifetime),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from db.CreatePasswordResetToken method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	body := fmt.Sprintf(
+		"Hi %s,\n\nUse the following code to reset your password:\n\n%s\n\n"+
+			"The code expires in 1 hour and can only be used once. "+
+			"If you did not ask to reset your password, you can ignore this email.",
+		user.Name, token,
+	)
+	go func() {
+		err := mailer.GetMailer().Send(user.Email, "Reset your password", body)
+		if err != nil {
+			log.Println("Error sending the password reset email.", err)
+		}
+	}()
+
+	utils.RespondWithJSON(w, 200, response)
+}
+
+func ResetPassword(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.ResetPasswordPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	invalidTokenResponse := map[string]any{
+		"type": "validation_error",
+		"msg": map[string]any{
+			"token": "The reset token is invalid or has expired",
+		},
+	}
+
+	resetToken, err := db.GetPasswordResetToken(ctx, database.GetPasswordResetTokenParams{
+		TokenHash: utils.HashToken(data.Token),
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetPasswordResetToken method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWithJSON(w, 400, invalidTokenResponse)
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(data.Password)
+	if err != nil {
+		log.Println("Error hashing password.", err)
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"password": "Bad password",
+			},
+		})
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	count, err := qtx.UsePasswordResetToken(ctx, database.UsePasswordResetTokenParams{
+		ID:     resetToken.ID,
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.UsePasswordResetToken method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, invalidTokenResponse)
+		return
+	}
+
+	err = qtx.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+		ID:        resetToken.UserID,
+		Password:  hashedPassword,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateUserPassword method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.InvalidatePasswordResetTokens(ctx, database.InvalidatePasswordResetTokensParams{
+		UserID: resetToken.UserID,
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.InvalidatePasswordResetTokens method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.DeleteActiveTokensBySub(ctx, resetToken.UserID)
+	if err != nil {
+		log.Println("Error from qtx.DeleteActiveTokensBySub method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(resetToken.UserID)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "Your password has been reset. Please log in again",
+	})
+}
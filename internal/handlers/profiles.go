@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// GetUserProfile returns the public profile of any user.
+// It must never expose the email address or other private data.
+func GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	id := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+
+	profile, err := db.GetUserProfile(ctx, id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserProfile method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"user": map[string]any{
+			"id":             profile.ID,
+			"name":           profile.Name,
+			"bio":            profile.Bio,
+			"points":         profile.Points,
+			"joined_at":      profile.CreatedAt,
+			"question_count": profile.QuestionCount,
+			"answer_count":   profile.AnswerCount,
+		},
+	})
+}
+
+func GetMe(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"user": userToMap(user),
+	})
+}
+
+func UpdateMe(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.ProfilePayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	if data.Name != nil {
+		user.Name = *data.Name
+	}
+	if data.Bio != nil {
+		user.Bio = *data.Bio
+	}
+
+	err = db.UpdateUserProfile(ctx, database.UpdateUserProfileParams{
+		Name:      user.Name,
+		Bio:       user.Bio,
+		UpdatedAt: time.Now(),
+		ID:        userId,
+	})
+	if err != nil {
+		log.Println("Error from db.UpdateUserProfile method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "Your profile has been updated",
+		"user": userToMap(user),
+	})
+}
@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/vuezy/go-ask-and-answer/internal/bounties"
 	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
 	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
@@ -17,58 +22,153 @@ import (
 var saveQuestionMutex sync.Mutex
 var closeQuestionMutex sync.Mutex
 
+const defaultQuestionsLimit = 20
+const maxQuestionsLimit = 100
+
+// questionCursor is the position of a question in the listing order
+// (closed, priority_level, responded_at, updated_at, then id to break ties).
+// Because the next page starts after this position instead of at an offset,
+// questions created in the meantime do not shift the pages.
+type questionCursor struct {
+	ID            int32     `json:"id"`
+	Closed        bool      `json:"closed"`
+	PriorityLevel int32     `json:"priority_level"`
+	RespondedAt   time.Time `json:"responded_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// parseQuestionsPage reads the limit and cursor query params.
+// The returned cursor is nil for the first page.
+func parseQuestionsPage(query url.Values) (int32, *questionCursor, map[string]any) {
+	errMsg := map[string]any{}
+
+	limit := int64(defaultQuestionsLimit)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxQuestionsLimit {
+			errMsg["limit"] = "Limit must be between 1 and 100"
+		}
+	}
+
+	var cursor *questionCursor
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		cursor = &questionCursor{}
+		if err := utils.DecodeCursor(cursorStr, cursor); err != nil {
+			errMsg["cursor"] = "The cursor is invalid"
+		}
+	}
+	return int32(limit), cursor, errMsg
+}
+
+func respondWithQuestionsPage(w http.ResponseWriter, questions []dto.QuestionSummary, limit int32) {
+	var nextCursor any
+	if len(questions) == int(limit) {
+		last := questions[len(questions)-1]
+		cursor, err := utils.EncodeCursor(questionCursor{
+			ID:            last.ID,
+			Closed:        last.Closed,
+			PriorityLevel: last.PriorityLevel,
+			RespondedAt:   last.RespondedAt,
+			UpdatedAt:     last.UpdatedAt,
+		})
+		if err != nil {
+			log.Println("Error encoding the cursor.", err)
+		} else {
+			nextCursor = cursor
+		}
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":        "success",
+		"questions":   questions,
+		"next_cursor": nextCursor,
+	})
+}
+
+func questionIds[T database.SearchQuestionsRow | database.GetQuestionsByUserIdRow](rows []T) []int32 {
+	ids := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, database.SearchQuestionsRow(row).ID)
+	}
+	return ids
+}
+
 func GetQuestions(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 	ctx := r.Context()
 	query := r.URL.Query()
 
+	limit, cursor, errMsg := parseQuestionsPage(query)
+	tags := parseTagFilter(query, errMsg)
+	if len(errMsg) > 0 {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg":  errMsg,
+		})
+		return
+	}
+
 	if userIdStr := query.Get("user_id"); userIdStr != "" {
 		userIdInt, err := strconv.ParseInt(userIdStr, 10, 32)
 		if err != nil {
 			log.Println("Error parsing user_id from query param.", err)
-			utils.RespondWithJSON(w, 200, map[string]any{
-				"type":      "success",
-				"questions": []any{},
-			})
+			respondWithQuestionsPage(w, []dto.QuestionSummary{}, limit)
 			return
 		}
 
-		questions, err := db.GetQuestionsByUserId(ctx, int32(userIdInt))
-		if err != nil || len(questions) == 0 {
-			if err != nil {
-				log.Println("Error from db.GetQuestionsByUserId method.", err)
-			}
-			utils.RespondWithJSON(w, 200, map[string]any{
-				"type":      "success",
-				"questions": []any{},
-			})
-			return
+		params := database.GetQuestionsByUserIdParams{
+			UserID:   int32(userIdInt),
+			TagCount: len(tags),
+			Tags:     tags,
+			Limit:    limit,
+		}
+		if cursor != nil {
+			params.CursorID = sql.NullInt32{Int32: cursor.ID, Valid: true}
+			params.CursorClosed = sql.NullBool{Bool: cursor.Closed, Valid: true}
+			params.CursorPriorityLevel = sql.NullInt32{Int32: cursor.PriorityLevel, Valid: true}
+			params.CursorRespondedAt = sql.NullTime{Time: cursor.RespondedAt, Valid: true}
+			params.CursorUpdatedAt = sql.NullTime{Time: cursor.UpdatedAt, Valid: true}
 		}
 
-		utils.RespondWithJSON(w, 200, map[string]any{
-			"type":      "success",
-			"questions": utils.ConvertStructToMap(questions),
-		})
+		questions, err := db.GetQuestionsByUserId(ctx, params)
+		if err != nil {
+			log.Println("Error from db.GetQuestionsByUserId method.", err)
+		}
+		questionTags, err := getQuestionTags(ctx, questionIds(questions))
+		if err != nil {
+			utils.RespondWith500Error(w)
+			return
+		}
+		respondWithQuestionsPage(w, dto.NewQuestionSummaries(questions, questionTags), limit)
 	} else {
 		title := query.Get("title")
 		title = "%" + strings.ReplaceAll(title, " ", "%") + "%"
 
-		questions, err := db.SearchQuestions(ctx, title)
-		if err != nil || len(questions) == 0 {
-			if err != nil {
-				log.Println("Error from db.SearchQuestions method.", err)
-			}
-			utils.RespondWithJSON(w, 200, map[string]any{
-				"type":      "success",
-				"questions": []any{},
-			})
-			return
+		params := database.SearchQuestionsParams{
+			Title:    title,
+			TagCount: len(tags),
+			Tags:     tags,
+			Limit:    limit,
+		}
+		if cursor != nil {
+			params.CursorID = sql.NullInt32{Int32: cursor.ID, Valid: true}
+			params.CursorClosed = sql.NullBool{Bool: cursor.Closed, Valid: true}
+			params.CursorPriorityLevel = sql.NullInt32{Int32: cursor.PriorityLevel, Valid: true}
+			params.CursorRespondedAt = sql.NullTime{Time: cursor.RespondedAt, Valid: true}
+			params.CursorUpdatedAt = sql.NullTime{Time: cursor.UpdatedAt, Valid: true}
 		}
 
-		utils.RespondWithJSON(w, 200, map[string]any{
-			"type":      "success",
-			"questions": utils.ConvertStructToMap(questions),
-		})
+		questions, err := db.SearchQuestions(ctx, params)
+		if err != nil {
+			log.Println("Error from db.SearchQuestions method.", err)
+		}
+		questionTags, err := getQuestionTags(ctx, questionIds(questions))
+		if err != nil {
+			utils.RespondWith500Error(w)
+			return
+		}
+		respondWithQuestionsPage(w, dto.NewQuestionSummaries(questions, questionTags), limit)
 	}
 }
 
@@ -86,9 +186,15 @@ func GetQuestionById(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tags, err := getQuestionTags(ctx, []int32{id})
+	if err != nil {
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type":     "success",
-		"question": utils.ConvertStructToMap(question),
+		"question": dto.NewQuestion(question, tags[id], dto.ViewerFromContext(ctx)),
 	})
 }
 
@@ -134,7 +240,7 @@ func CreateQuestion(w http.ResponseWriter, r *http.Request) {
 	}
 	qtx := db.WithTx(tx)
 
-	err = qtx.CreateQuestion(ctx, database.CreateQuestionParams{
+	questionId, err := qtx.CreateQuestion(ctx, database.CreateQuestionParams{
 		Title:         question.Title,
 		Body:          question.Body,
 		PriorityLevel: question.PriorityLevel,
@@ -150,6 +256,13 @@ func CreateQuestion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = createQuestionRevision(ctx, qtx, int32(questionId), question.Title, question.Body, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	err = qtx.RemoveUserCredit(ctx, database.RemoveUserCreditParams{
 		ID:        userId,
 		Credits:   question.PriorityLevel,
@@ -162,12 +275,34 @@ func CreateQuestion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = utils.RecordCreditsChange(ctx, qtx, r, userId, userId, -question.PriorityLevel, "question_priority", int32(questionId))
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = bounties.Escrow(ctx, qtx, int32(questionId), question.PriorityLevel)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = setQuestionTags(ctx, qtx, int32(questionId), question.Tags)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Println("Error commiting the transaction.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	updateSearchIndex(ctx, int32(questionId))
 
 	utils.RespondWithJSON(w, 201, map[string]any{
 		"type": "success",
@@ -196,10 +331,18 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith404Error(w)
 		return
 	}
-	if question.UserID != userId {
+	allowed, moderating := checkContentPermission(ctx, userId, question.UserID)
+	if !allowed {
 		utils.RespondWith403Error(w)
 		return
 	}
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot update the question because it has been closed",
+		})
+		return
+	}
 
 	questionPayload, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.QuestionPayload)
 	if !ok {
@@ -209,6 +352,10 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	priorityLevel := max(0, questionPayload.PriorityLevel-question.PriorityLevel)
+	if moderating {
+		// Moderators cannot raise the priority level with their own credits
+		priorityLevel = 0
+	}
 	user, err := db.GetUserPointsAndCredits(ctx, userId)
 	if err != nil || priorityLevel > user.Credits {
 		if err != nil && err != sql.ErrNoRows {
@@ -232,9 +379,16 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 	}
 	qtx := db.WithTx(tx)
 
+	err = bounties.Escrow(ctx, qtx, questionId, priorityLevel)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	err = qtx.UpdateQuestion(ctx, database.UpdateQuestionParams{
 		ID:            questionId,
-		UserID:        userId,
+		UserID:        question.UserID,
 		Title:         questionPayload.Title,
 		Body:          questionPayload.Body,
 		PriorityLevel: priorityLevel,
@@ -247,6 +401,15 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if questionPayload.Title != question.Title || questionPayload.Body != question.Body {
+		err = createQuestionRevision(ctx, qtx, questionId, questionPayload.Title, questionPayload.Body, userId)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
 	err = qtx.RemoveUserCredit(ctx, database.RemoveUserCreditParams{
 		ID:        userId,
 		Credits:   priorityLevel,
@@ -259,12 +422,39 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = utils.RecordCreditsChange(ctx, qtx, r, userId, userId, -priorityLevel, "question_priority", questionId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// The tags are kept if they are left out
+	if questionPayload.Tags != nil {
+		err = setQuestionTags(ctx, qtx, questionId, questionPayload.Tags)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "edit", "question", questionId, question.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Println("Error commiting the transaction.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	updateSearchIndex(ctx, questionId)
 
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type": "success",
@@ -273,6 +463,10 @@ func UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 }
 
 func DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	// Moderators pay the answerers like closing the question does
+	closeQuestionMutex.Lock()
+	defer closeQuestionMutex.Unlock()
+
 	db := database.GetDB()
 	ctx := r.Context()
 	userId := utils.GetTokenSubject(ctx)
@@ -290,27 +484,154 @@ func DeleteQuestion(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith404Error(w)
 		return
 	}
-	if question.UserID != userId {
+	allowed, moderating := checkContentPermission(ctx, userId, question.UserID)
+	if !allowed {
 		utils.RespondWith403Error(w)
 		return
 	}
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot delete the question because it has been closed",
+		})
+		return
+	}
 
-	err = db.DeleteQuestion(ctx, database.DeleteQuestionParams{
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	// Answerers would lose the points of their upvotes, so only moderators
+	// can delete a question once an answer has a positive score.
+	// The answerers are paid their positive votes as credits before the answers are deleted.
+	if moderating {
+		err = payAnswerVotes(ctx, qtx, r, userId, questionId, false)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	} else {
+		upvotedAnswers, err := qtx.CountUpvotedAnswersByQuestionId(ctx, questionId)
+		if err != nil {
+			log.Println("Error from qtx.CountUpvotedAnswersByQuestionId method.", err)
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+		if upvotedAnswers > 0 {
+			tx.Rollback()
+			utils.RespondWithJSON(w, 400, map[string]any{
+				"type": "error",
+				"msg":  "Cannot delete the question because it has upvoted answers",
+			})
+			return
+		}
+	}
+
+	// The bounty is deleted together with the question,
+	// so the credits in escrow are given back first
+	err = bounties.Refund(ctx, qtx, r, userId, questionId, question.UserID)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.DeleteQuestion(ctx, database.DeleteQuestionParams{
 		ID:     questionId,
-		UserID: userId,
+		UserID: question.UserID,
+	})
+	if err != nil {
+		log.Println("Error from qtx.DeleteQuestion method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "delete", "question", questionId, question.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	err = utils.RecordAuditEvent(ctx, qtx, r, userId, utils.AUDIT_QUESTION_DELETE, map[string]any{
+		"question_id": questionId,
+		"owner_id":    question.UserID,
+		"title":       question.Title,
 	})
 	if err != nil {
-		log.Println("Error from db.DeleteQuestion method.", err)
+		tx.Rollback()
 		utils.RespondWith500Error(w)
 		return
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	updateSearchIndex(ctx, questionId)
+
 	utils.RespondWithJSON(w, 200, map[string]any{
 		"type": "success",
 		"msg":  "The question has been deleted",
 	})
 }
 
+// payAnswerVotes gives every answerer of the question their votes as credits.
+// Negative scores take credits away only if deductNegative is true: closing a question
+// settles the scores, while deleting it only compensates the answerers.
+func payAnswerVotes(
+	ctx context.Context,
+	q *database.Queries,
+	r *http.Request,
+	actorId int32,
+	questionId int32,
+	deductNegative bool,
+) error {
+	answers, err := q.GetAnswersByQuestionId(ctx, questionId)
+	if err != nil {
+		log.Println("Error from q.GetAnswersByQuestionId method.", err)
+		return err
+	}
+
+	for _, answer := range answers {
+		amount := answer.Votes
+		if !deductNegative {
+			amount = max(0, amount)
+		}
+		if amount == 0 {
+			continue
+		}
+
+		err = q.AddUserCredit(ctx, database.AddUserCreditParams{
+			ID:        answer.UserID,
+			Credits:   amount,
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			log.Println("Error from q.AddUserCredit method.", err)
+			return err
+		}
+
+		err = utils.RecordCreditsChange(ctx, q, r, actorId, answer.UserID, amount, "answer_votes", questionId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func CloseQuestion(w http.ResponseWriter, r *http.Request) {
 	closeQuestionMutex.Lock()
 	defer closeQuestionMutex.Unlock()
@@ -332,10 +653,18 @@ func CloseQuestion(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith404Error(w)
 		return
 	}
-	if question.UserID != userId {
+	allowed, moderating := checkContentPermission(ctx, userId, question.UserID)
+	if !allowed {
 		utils.RespondWith403Error(w)
 		return
 	}
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "The question has already been closed",
+		})
+		return
+	}
 
 	conn := database.GetDBConn()
 	tx, err := conn.Begin()
@@ -346,9 +675,18 @@ func CloseQuestion(w http.ResponseWriter, r *http.Request) {
 	}
 	qtx := db.WithTx(tx)
 
-	err = qtx.CloseQuestion(ctx, database.CloseQuestionParams{
+	err = bounties.Settle(ctx, qtx, r, userId, question)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	// Only succeeds if the question is still open,
+	// so the answerers cannot be paid twice
+	closed, err := qtx.CloseQuestion(ctx, database.CloseQuestionParams{
 		ID:        questionId,
-		UserID:    userId,
+		UserID:    question.UserID,
 		UpdatedAt: time.Now(),
 	})
 	if err != nil {
@@ -357,29 +695,41 @@ func CloseQuestion(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWith500Error(w)
 		return
 	}
+	if closed == 0 {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "The question has already been closed",
+		})
+		return
+	}
 
-	answers, err := qtx.GetAnswersByQuestionId(ctx, questionId)
+	err = payAnswerVotes(ctx, qtx, r, userId, questionId, true)
 	if err != nil {
-		log.Println("Error from qtx.GetAnswersByQuestionId method.", err)
 		tx.Rollback()
 		utils.RespondWith500Error(w)
 		return
 	}
 
-	for _, answer := range answers {
-		err = qtx.AddUserCredit(ctx, database.AddUserCreditParams{
-			ID:        answer.UserID,
-			Credits:   answer.Votes,
-			UpdatedAt: time.Now(),
-		})
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "close", "question", questionId, question.UserID)
 		if err != nil {
-			log.Println("Error from qtx.AddUserCredit method.", err)
 			tx.Rollback()
 			utils.RespondWith500Error(w)
 			return
 		}
 	}
 
+	err = utils.RecordAuditEvent(ctx, qtx, r, userId, utils.AUDIT_QUESTION_CLOSE, map[string]any{
+		"question_id": questionId,
+		"owner_id":    question.UserID,
+	})
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		log.Println("Error commiting the transaction.", err)
@@ -392,3 +742,153 @@ func CloseQuestion(w http.ResponseWriter, r *http.Request) {
 		"msg":  "The question has been closed",
 	})
 }
+
+// AcceptAnswer marks the answer that solved the problem of the asker.
+// The answer is pinned at the top of the answers and its author earns the
+// accepted answer reward. Only one answer can be accepted per question.
+func AcceptAnswer(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	questionId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	answerIdInt, err := strconv.ParseInt(chi.URLParam(r, "answerId"), 10, 32)
+	if err != nil {
+		log.Println("Error parsing answerId from URL param.", err)
+		utils.RespondWith404Error(w)
+		return
+	}
+	answerId := int32(answerIdInt)
+
+	question, err := db.GetQuestionById(ctx, questionId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetQuestionById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+	if question.UserID != userId {
+		utils.RespondWith403Error(w)
+		return
+	}
+	// The bounty of a closed question has already been settled
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot accept an answer because the question has been closed",
+		})
+		return
+	}
+
+	answer, err := db.GetAnswerById(ctx, answerId)
+	if err != nil || answer.QuestionID != questionId {
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error from db.GetAnswerById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+	if answer.UserID == userId {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "You cannot accept your own answer",
+		})
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	// Only succeeds if no answer has been accepted yet and the question is still open,
+	// so the reward cannot be paid twice or after the bounty has been settled
+	accepted, err := qtx.AcceptAnswer(ctx, database.AcceptAnswerParams{
+		AcceptedAnswerID: sql.NullInt32{Int32: answerId, Valid: true},
+		UpdatedAt:        time.Now(),
+		ID:               questionId,
+		UserID:           userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.AcceptAnswer method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if accepted == 0 {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "An answer has already been accepted or the question has been closed",
+		})
+		return
+	}
+
+	// The answer may have been deleted since it was read. The question row is
+	// locked by now, which DeleteAnswer locks before deleting the answer.
+	count, err := qtx.CheckIfAnswerExists(ctx, answerId)
+	if err != nil {
+		log.Println("Error from qtx.CheckIfAnswerExists method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		tx.Rollback()
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	reward := utils.GetAcceptedAnswerReward()
+	err = qtx.UpdateUserPoints(ctx, database.UpdateUserPointsParams{
+		ID:        answer.UserID,
+		Points:    reward.Points,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateUserPoints method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.AddUserCredit(ctx, database.AddUserCreditParams{
+		ID:        answer.UserID,
+		Credits:   reward.Credits,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.AddUserCredit method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = utils.RecordCreditsChange(ctx, qtx, r, userId, answer.UserID, reward.Credits, "accepted_answer", questionId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The answer has been accepted",
+	})
+}
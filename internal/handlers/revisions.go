@@ -0,0 +1,370 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// Every version of the text of a question or an answer is kept as a revision,
+// so edits (and the votes cast before them) can be reviewed and rolled back.
+
+func createQuestionRevision(
+	ctx context.Context,
+	qtx *database.Queries,
+	questionId int32,
+	title string,
+	body string,
+	editorId int32,
+) error {
+	err := qtx.CreateRevision(ctx, database.CreateRevisionParams{
+		QuestionID: sql.NullInt32{Int32: questionId, Valid: true},
+		Title:      sql.NullString{String: title, Valid: true},
+		Body:       body,
+		EditorID:   editorId,
+		CreatedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.CreateRevision method.", err)
+	}
+	return err
+}
+
+func createAnswerRevision(ctx context.Context, qtx *database.Queries, answerId int32, body string, editorId int32) error {
+	err := qtx.CreateRevision(ctx, database.CreateRevisionParams{
+		AnswerID:  sql.NullInt32{Int32: answerId, Valid: true},
+		Body:      body,
+		EditorID:  editorId,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.CreateRevision method.", err)
+	}
+	return err
+}
+
+// diffRevisions compares the revisions selected with the from and to query params
+// (revision IDs). By default the latest revision is compared with the one before it.
+func diffRevisions(revisions []dto.Revision, query url.Values) (map[string]any, map[string]any) {
+	errMsg := map[string]any{}
+	if len(revisions) == 0 {
+		return nil, errMsg
+	}
+
+	to := revisions[len(revisions)-1]
+	from := revisions[max(0, len(revisions)-2)]
+	for param, revision := range map[string]*dto.Revision{"from": &from, "to": &to} {
+		idStr := query.Get(param)
+		if idStr == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 32)
+		found := false
+		for _, candidate := range revisions {
+			if err == nil && int64(candidate.ID) == id {
+				*revision = candidate
+				found = true
+				break
+			}
+		}
+		if !found {
+			errMsg[param] = "The revision does not exist"
+		}
+	}
+
+	diff := map[string]any{
+		"from": from.ID,
+		"to":   to.ID,
+		"body": dto.NewDiff(utils.DiffLines(from.Body, to.Body)),
+	}
+	if from.Title != nil && to.Title != nil {
+		diff["title"] = dto.NewDiff(utils.DiffLines(*from.Title, *to.Title))
+	}
+	return diff, errMsg
+}
+
+func respondWithRevisions(w http.ResponseWriter, revisions []dto.Revision, query url.Values) {
+	diff, errMsg := diffRevisions(revisions, query)
+	if len(errMsg) > 0 {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg":  errMsg,
+		})
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":      "success",
+		"revisions": revisions,
+		"diff":      diff,
+	})
+}
+
+// parseRevisionId reads the revisionId URL param. It responds with 404 if it is invalid.
+func parseRevisionId(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	revisionId, err := strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 32)
+	if err != nil {
+		log.Println("Error parsing revisionId from URL param.", err)
+		utils.RespondWith404Error(w)
+		return 0, false
+	}
+	return int32(revisionId), true
+}
+
+func GetQuestionRevisions(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	questionId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+
+	revisions, err := db.GetRevisionsByQuestionId(ctx, questionId)
+	if err != nil {
+		log.Println("Error from db.GetRevisionsByQuestionId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if len(revisions) == 0 {
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	respondWithRevisions(w, dto.NewRevisions(revisions), r.URL.Query())
+}
+
+func GetAnswerRevisions(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	answerId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+
+	revisions, err := db.GetRevisionsByAnswerId(ctx, answerId)
+	if err != nil {
+		log.Println("Error from db.GetRevisionsByAnswerId method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if len(revisions) == 0 {
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	respondWithRevisions(w, dto.NewRevisions(revisions), r.URL.Query())
+}
+
+// RollbackQuestion restores the title and body of a previous revision.
+// The restored text is saved as a new revision, so the history is never rewritten.
+func RollbackQuestion(w http.ResponseWriter, r *http.Request) {
+	saveQuestionMutex.Lock()
+	defer saveQuestionMutex.Unlock()
+
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	questionId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	revisionId, ok := parseRevisionId(w, r)
+	if !ok {
+		return
+	}
+
+	question, err := db.GetQuestionById(ctx, questionId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetQuestionById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+	allowed, moderating := checkContentPermission(ctx, userId, question.UserID)
+	if !allowed {
+		utils.RespondWith403Error(w)
+		return
+	}
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot update the question because it has been closed",
+		})
+		return
+	}
+
+	revision, err := db.GetRevisionById(ctx, revisionId)
+	if err != nil || revision.QuestionID.Int32 != questionId {
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error from db.GetRevisionById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.UpdateQuestion(ctx, database.UpdateQuestionParams{
+		ID:            questionId,
+		UserID:        question.UserID,
+		Title:         revision.Title.String,
+		Body:          revision.Body,
+		PriorityLevel: 0,
+		UpdatedAt:     time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateQuestion method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = createQuestionRevision(ctx, qtx, questionId, revision.Title.String, revision.Body, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "rollback", "question", questionId, question.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	updateSearchIndex(ctx, questionId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The question has been rolled back",
+	})
+}
+
+// RollbackAnswer restores the body of a previous revision as a new revision.
+func RollbackAnswer(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	answerId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	revisionId, ok := parseRevisionId(w, r)
+	if !ok {
+		return
+	}
+
+	answer, err := db.GetAnswerById(ctx, answerId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetAnswerById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+	allowed, moderating := checkContentPermission(ctx, userId, answer.UserID)
+	if !allowed {
+		utils.RespondWith403Error(w)
+		return
+	}
+
+	question, err := db.GetQuestionById(ctx, answer.QuestionID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetQuestionById method.", err)
+		}
+		utils.RespondWith500Error(w)
+		return
+	}
+	if question.Closed {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Cannot update the answer because the question has been closed",
+		})
+		return
+	}
+
+	revision, err := db.GetRevisionById(ctx, revisionId)
+	if err != nil || revision.AnswerID.Int32 != answerId {
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error from db.GetRevisionById method.", err)
+		}
+		utils.RespondWith404Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.UpdateAnswer(ctx, database.UpdateAnswerParams{
+		ID:        answerId,
+		UserID:    answer.UserID,
+		Body:      revision.Body,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateAnswer method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = createAnswerRevision(ctx, qtx, answerId, revision.Body, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	if moderating {
+		err = recordModerationAction(ctx, qtx, userId, "rollback", "answer", answerId, answer.UserID)
+		if err != nil {
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	updateSearchIndex(ctx, answer.QuestionID)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The answer has been rolled back",
+	})
+}
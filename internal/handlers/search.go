@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/search"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const defaultSearchLimit = 20
+const maxSearchLimit = 50
+const maxSearchQueryLength = 100
+
+// updateSearchIndex tells the search index that the question or one of its answers
+// has changed. It must be called after the change has been committed.
+// Failures are only logged, the search results are fixed by the next change.
+func updateSearchIndex(ctx context.Context, questionId int32) {
+	db := database.GetDB()
+
+	err := search.GetSearchIndex().Update(ctx, questionId, func() (*search.Document, error) {
+		question, err := db.GetQuestionById(ctx, questionId)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		answers, err := db.GetAnswersByQuestionId(ctx, questionId)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := &search.Document{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Body:       question.Body,
+			Answers:    make([]string, 0, len(answers)),
+		}
+		for _, answer := range answers {
+			doc.Answers = append(doc.Answers, answer.Body)
+		}
+		return doc, nil
+	})
+	if err != nil {
+		log.Println("Error updating the search index.", err)
+	}
+}
+
+// SearchQuestions searches the titles, bodies and answers of the questions
+// for the words of the q query param, the most relevant first.
+func SearchQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	errMsg := map[string]any{}
+
+	q := strings.TrimSpace(query.Get("q"))
+	if len(search.Tokenize(q)) == 0 || len([]rune(q)) > maxSearchQueryLength {
+		errMsg["q"] = "Search query must contain 1-100 characters with at least one word"
+	}
+
+	limit := int64(defaultSearchLimit)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxSearchLimit {
+			errMsg["limit"] = "Limit must be between 1 and 50"
+		}
+	}
+
+	if len(errMsg) > 0 {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg":  errMsg,
+		})
+		return
+	}
+
+	results, err := search.GetSearchIndex().Search(ctx, q, int(limit))
+	if err != nil {
+		log.Println("Error from searchIndex.Search method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":    "success",
+		"results": dto.NewSearchResults(results),
+	})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const defaultDeviceName = "Unknown device"
+
+// createSession issues a new token pair for the user and records it
+// as a separate active session, so logging in on one device does not
+// log the user out of the others. The session ID doubles as the family ID
+// of every refresh token rotated from this pair.
+func createSession(
+	ctx context.Context,
+	q *database.Queries,
+	r *http.Request,
+	userId int32,
+	role string,
+	deviceName string,
+) (string, string, error) {
+	jti, accessTokenStr, refreshTokenStr, err := utils.IssueJWT(userId, role)
+	if err != nil {
+		return "", "", err
+	}
+
+	if deviceName == "" {
+		deviceName = defaultDeviceName
+	}
+
+	sessionId, err := q.CreateActiveToken(ctx, database.CreateActiveTokenParams{
+		Sub:        userId,
+		Jti:        jti,
+		DeviceName: deviceName,
+		UserAgent:  utils.GetUserAgent(r),
+		IpAddress:  utils.GetClientIP(r),
+		CreatedAt:  time.Now(),
+		LastSeenAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateActiveToken method.", err)
+		return "", "", err
+	}
+
+	err = q.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		Jti:       jti,
+		FamilyID:  int32(sessionId),
+		Sub:       userId,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateRefreshToken method.", err)
+		return "", "", err
+	}
+	return accessTokenStr, refreshTokenStr, nil
+}
+
+// rotateSession replaces the token pair of an existing session and records
+// the new refresh token as a child of the one it has been rotated from.
+func rotateSession(
+	ctx context.Context,
+	q *database.Queries,
+	r *http.Request,
+	userId int32,
+	role string,
+	sessionId int32,
+	parentJti string,
+) (string, string, error) {
+	jti, accessTokenStr, refreshTokenStr, err := utils.IssueJWT(userId, role)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = q.SetActiveToken(ctx, database.SetActiveTokenParams{
+		ID:         sessionId,
+		Jti:        jti,
+		IpAddress:  utils.GetClientIP(r),
+		LastSeenAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.SetActiveToken method.", err)
+		return "", "", err
+	}
+
+	err = q.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		Jti:       jti,
+		ParentJti: sql.NullString{String: parentJti, Valid: true},
+		FamilyID:  sessionId,
+		Sub:       userId,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateRefreshToken method.", err)
+		return "", "", err
+	}
+	return accessTokenStr, refreshTokenStr, nil
+}
+
+// GetSessions lists the sessions of the user. last_seen_at is only updated
+// when the session is created or refreshed, so requests do not have to write to the database.
+func GetSessions(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	_, token, _ := utils.JWTAuthFromContext(ctx)
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	sessions, err := db.GetActiveTokensBySub(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetActiveTokensBySub method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	response := make([]map[string]any, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, map[string]any{
+			"id":           session.ID,
+			"device_name":  session.DeviceName,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IpAddress,
+			"created_at":   session.CreatedAt,
+			"last_seen_at": session.LastSeenAt,
+			"current":      session.Jti == token.JwtID(),
+		})
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":     "success",
+		"sessions": response,
+	})
+}
+
+func DeleteSession(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	sessionId := int32(ctx.Value(utils.PARSED_ID_CTX).(int64))
+	count, err := db.DeleteActiveToken(ctx, database.DeleteActiveTokenParams{
+		ID:  sessionId,
+		Sub: userId,
+	})
+	if err != nil {
+		log.Println("Error from db.DeleteActiveToken method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		utils.RespondWith404Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(userId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "The session has been revoked",
+	})
+}
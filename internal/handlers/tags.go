@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/dto"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const defaultTagsLimit = 10
+const maxTagsLimit = 50
+
+// normalizeTags returns the validated tags in their stored form, without duplicates.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag, _ = utils.NormalizeTag(tag)
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// setQuestionTags replaces the tags of the question, creating the tags
+// that do not exist yet. It is meant to be called in a transaction.
+func setQuestionTags(ctx context.Context, qtx *database.Queries, questionId int32, tags []string) error {
+	err := qtx.DeleteQuestionTags(ctx, questionId)
+	if err != nil {
+		log.Println("Error from qtx.DeleteQuestionTags method.", err)
+		return err
+	}
+
+	for _, tag := range normalizeTags(tags) {
+		err = qtx.CreateTag(ctx, database.CreateTagParams{
+			Name:      tag,
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			log.Println("Error from qtx.CreateTag method.", err)
+			return err
+		}
+
+		tagId, err := qtx.GetTagIdByName(ctx, tag)
+		if err != nil {
+			log.Println("Error from qtx.GetTagIdByName method.", err)
+			return err
+		}
+
+		err = qtx.AddQuestionTag(ctx, database.AddQuestionTagParams{
+			QuestionID: questionId,
+			TagID:      tagId,
+		})
+		if err != nil {
+			log.Println("Error from qtx.AddQuestionTag method.", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getQuestionTags returns the tags of the questions, mapped by question ID.
+func getQuestionTags(ctx context.Context, questionIds []int32) (map[int32][]string, error) {
+	db := database.GetDB()
+
+	tags := map[int32][]string{}
+	if len(questionIds) == 0 {
+		return tags, nil
+	}
+
+	rows, err := db.GetTagsByQuestionIds(ctx, questionIds)
+	if err != nil {
+		log.Println("Error from db.GetTagsByQuestionIds method.", err)
+		return nil, err
+	}
+	for _, row := range rows {
+		tags[row.QuestionID] = append(tags[row.QuestionID], row.Name)
+	}
+	return tags, nil
+}
+
+// parseTagFilter reads the tag query params. Questions must have all the given tags.
+func parseTagFilter(query url.Values, errMsg map[string]any) []string {
+	tags := query["tag"]
+	if len(tags) > utils.MAX_TAGS_PER_QUESTION {
+		errMsg["tag"] = "Filter by up to 5 tags"
+		return nil
+	}
+	for _, tag := range tags {
+		if _, valid := utils.NormalizeTag(tag); !valid {
+			errMsg["tag"] = "Tags contain 1-30 letters, digits or the characters + # . -"
+			return nil
+		}
+	}
+	return normalizeTags(tags)
+}
+
+// GetTags returns the tags in use, the most used first. The optional prefix
+// query param narrows them down, for autocompletion.
+func GetTags(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	query := r.URL.Query()
+	errMsg := map[string]any{}
+
+	prefix := strings.ToLower(strings.TrimSpace(query.Get("prefix")))
+	if len(prefix) > 30 {
+		errMsg["prefix"] = "Prefix must not be longer than 30 characters"
+	}
+
+	limit := int64(defaultTagsLimit)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxTagsLimit {
+			errMsg["limit"] = "Limit must be between 1 and 50"
+		}
+	}
+
+	if len(errMsg) > 0 {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg":  errMsg,
+		})
+		return
+	}
+
+	// Escape the LIKE wildcards, tags never contain them but the prefix could
+	prefix = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix)
+	tags, err := db.GetTags(ctx, database.GetTagsParams{
+		Name:  prefix + "%",
+		Limit: int32(limit),
+	})
+	if err != nil {
+		log.Println("Error from db.GetTags method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"tags": dto.NewTags(tags),
+	})
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/limiter"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const recoveryCodeCount = 10
+
+func getTOTPIssuer() string {
+	issuer := os.Getenv("TOTP_ISSUER")
+	if issuer == "" {
+		return "Ask&Answer"
+	}
+	return issuer
+}
+
+// checkTOTPCode accepts a TOTP code only once. Once a code has been accepted,
+// it and the codes of earlier time steps are rejected.
+func checkTOTPCode(ctx context.Context, q *database.Queries, user database.User, code string) (bool, error) {
+	counter, ok := utils.ValidateTOTP(user.TotpSecret.String, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	count, err := q.UseUserTotpCounter(ctx, database.UseUserTotpCounterParams{
+		TotpLastCounter:   counter,
+		ID:                user.ID,
+		TotpLastCounter_2: counter,
+	})
+	if err != nil {
+		log.Println("Error from db.UseUserTotpCounter method.", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// checkTwoFactorCode accepts either a TOTP code or an unused recovery code.
+// A recovery code is marked as used as soon as it is accepted.
+func checkTwoFactorCode(ctx context.Context, q *database.Queries, user database.User, code string) (bool, error) {
+	valid, err := checkTOTPCode(ctx, q, user, code)
+	if err != nil || valid {
+		return valid, err
+	}
+
+	count, err := q.UseRecoveryCode(ctx, database.UseRecoveryCodeParams{
+		UsedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+		UserID:   user.ID,
+		CodeHash: utils.HashRecoveryCode(code),
+	})
+	if err != nil {
+		log.Println("Error from db.UseRecoveryCode method.", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetUserById method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if user.TotpEnabled {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Two-factor authentication is already enabled",
+		})
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		log.Println("Error generating a TOTP secret.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = db.SetUserTotpSecret(ctx, database.SetUserTotpSecretParams{
+		TotpSecret: sql.NullString{String: secret, Valid: true},
+		UpdatedAt:  time.Now(),
+		ID:         userId,
+	})
+	if err != nil {
+		log.Println("Error from db.SetUserTotpSecret method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":             "success",
+		"msg":              "Scan the QR code with your authenticator app, then confirm with a code to enable two-factor authentication",
+		"secret":           secret,
+		"provisioning_uri": utils.TOTPProvisioningURI(secret, user.Email, getTOTPIssuer()),
+	})
+}
+
+func EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.TwoFactorCodePayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetUserById method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if user.TotpEnabled || !user.TotpSecret.Valid {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Two-factor authentication is already enabled or has not been set up",
+		})
+		return
+	}
+
+	valid, err := checkTOTPCode(ctx, db, user, data.Code)
+	if err != nil {
+		utils.RespondWith500Error(w)
+		return
+	}
+	if !valid {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"code": "Invalid code",
+			},
+		})
+		return
+	}
+
+	recoveryCodes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		log.Println("Error generating recovery codes.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.EnableUserTotp(ctx, database.EnableUserTotpParams{
+		UpdatedAt: time.Now(),
+		ID:        userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.EnableUserTotp method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.DeleteRecoveryCodes(ctx, userId)
+	if err != nil {
+		log.Println("Error from qtx.DeleteRecoveryCodes method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	for _, code := range recoveryCodes {
+		err = qtx.CreateRecoveryCode(ctx, database.CreateRecoveryCodeParams{
+			UserID:    userId,
+			CodeHash:  utils.HashRecoveryCode(code),
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			log.Println("Error from qtx.CreateRecoveryCode method.", err)
+			tx.Rollback()
+			utils.RespondWith500Error(w)
+			return
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":           "success",
+		"msg":            "Two-factor authentication has been enabled. Store the recovery codes in a safe place, they will not be shown again",
+		"recovery_codes": recoveryCodes,
+	})
+}
+
+func DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.TwoFactorCodePayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetUserById method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if !user.TotpEnabled {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Two-factor authentication is not enabled",
+		})
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	valid, err := checkTwoFactorCode(ctx, qtx, user, data.Code)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if !valid {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"code": "Invalid code",
+			},
+		})
+		return
+	}
+
+	err = qtx.DisableUserTotp(ctx, database.DisableUserTotpParams{
+		UpdatedAt: time.Now(),
+		ID:        userId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.DisableUserTotp method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.DeleteRecoveryCodes(ctx, userId)
+	if err != nil {
+		log.Println("Error from qtx.DeleteRecoveryCodes method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "Two-factor authentication has been disabled",
+	})
+}
+
+func LoginWithTwoFactor(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.TwoFactorLoginPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	invalidChallengeResponse := map[string]any{
+		"type": "authentication_error",
+		"msg":  "The challenge token is invalid or has expired. Please log in again",
+	}
+
+	userId, jti, expiresAt, ok := utils.VerifyChallengeToken(data.ChallengeToken)
+	if !ok {
+		utils.RespondWithJSON(w, 401, invalidChallengeResponse)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		log.Println("Error from db.GetUserById method.", err)
+		utils.RespondWithJSON(w, 401, invalidChallengeResponse)
+		return
+	}
+	if !user.TotpEnabled {
+		utils.RespondWithJSON(w, 401, invalidChallengeResponse)
+		return
+	}
+
+	// Every wrong code counts as a failed login, so the 6-digit codes
+	// cannot be guessed by requesting new challenge tokens
+	loginLimiter := limiter.GetLoginLimiter()
+	ip := utils.GetClientIP(r)
+	retryAfter, err := loginLimiter.Check(ctx, user.Email, ip)
+	if err != nil {
+		log.Println("Error from loginLimiter.Check method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if retryAfter > 0 {
+		utils.RecordAuditEvent(ctx, db, r, user.ID, utils.AUDIT_LOGIN_FAILED, map[string]any{
+			"email":  user.Email,
+			"reason": "rate_limited",
+		})
+		utils.RespondWith429Error(w, retryAfter)
+		return
+	}
+
+	// The challenge token is used up before the code is checked, so a replayed
+	// token cannot use up the TOTP time steps or the recovery codes of the user.
+	// A wrong code therefore requires logging in with the password again.
+	err = db.DeleteExpiredChallengeTokens(ctx, database.DeleteExpiredChallengeTokensParams{
+		UserID:    user.ID,
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from db.DeleteExpiredChallengeTokens method.", err)
+	}
+	count, err := db.UseChallengeToken(ctx, database.UseChallengeTokenParams{
+		Jti:       jti,
+		UserID:    user.ID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		log.Println("Error from db.UseChallengeToken method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		utils.RespondWithJSON(w, 401, invalidChallengeResponse)
+		return
+	}
+
+	valid, err := checkTwoFactorCode(ctx, db, user, data.Code)
+	if err != nil {
+		utils.RespondWith500Error(w)
+		return
+	}
+	if !valid {
+		recordLoginFailure(r, user.ID, user.Email, "wrong_code")
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"code": "Invalid code. Please log in again",
+			},
+		})
+		return
+	}
+
+	err = loginLimiter.RecordSuccess(ctx, user.Email, ip)
+	if err != nil {
+		log.Println("Error from loginLimiter.RecordSuccess method.", err)
+	}
+
+	accessTokenStr, refreshTokenStr, err := createSession(ctx, db, r, user.ID, user.Role, data.DeviceName)
+	if err != nil {
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.RecordAuditEvent(ctx, db, r, user.ID, utils.AUDIT_LOGIN, map[string]any{
+		"two_factor":  true,
+		"device_name": data.DeviceName,
+	})
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":          "success",
+		"user":          userToMap(user),
+		"access_token":  accessTokenStr,
+		"refresh_token": refreshTokenStr,
+	})
+}
@@ -1,16 +1,66 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/limiter"
 	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
 
+// recordLoginFailure records the failure in the audit log.
+// The login limiter has already counted it when the attempt was checked.
+// userId is 0 if no user has the email.
+func recordLoginFailure(r *http.Request, userId int32, email string, reason string) {
+	utils.RecordAuditEvent(r.Context(), database.GetDB(), r, userId, utils.AUDIT_LOGIN_FAILED, map[string]any{
+		"email":  email,
+		"reason": reason,
+	})
+}
+
+// rehashPasswordIfNeeded replaces a hash created with outdated parameters.
+// Failures are only logged, the user can still log in with the old hash.
+func rehashPasswordIfNeeded(ctx context.Context, q *database.Queries, user database.User, password string) {
+	if !utils.PasswordNeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		log.Println("Error from utils.HashPassword function.", err)
+		return
+	}
+
+	err = q.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+		Password:  hashedPassword,
+		UpdatedAt: time.Now(),
+		ID:        user.ID,
+	})
+	if err != nil {
+		log.Println("Error from q.UpdateUserPassword method.", err)
+	}
+}
+
+func userToMap(user database.User) map[string]any {
+	return map[string]any{
+		"id":                 user.ID,
+		"name":               user.Name,
+		"email":              user.Email,
+		"points":             user.Points,
+		"credits":            user.Credits,
+		"verified":           user.VerifiedAt.Valid,
+		"two_factor_enabled": user.TotpEnabled,
+		"role":               user.Role,
+		"bio":                user.Bio,
+		"created_at":         user.CreatedAt,
+	}
+}
+
 func Login(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 	ctx := r.Context()
@@ -21,9 +71,27 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	loginLimiter := limiter.GetLoginLimiter()
+	ip := utils.GetClientIP(r)
+	retryAfter, err := loginLimiter.Check(ctx, credentials.Email, ip)
+	if err != nil {
+		log.Println("Error from loginLimiter.Check method.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	if retryAfter > 0 {
+		utils.RecordAuditEvent(ctx, db, r, 0, utils.AUDIT_LOGIN_FAILED, map[string]any{
+			"email":  credentials.Email,
+			"reason": "rate_limited",
+		})
+		utils.RespondWith429Error(w, retryAfter)
+		return
+	}
+
 	user, err := db.Login(ctx, credentials.Email)
 	if err != nil {
 		log.Println("Error from db.Login method.", err)
+		recordLoginFailure(r, 0, credentials.Email, "unknown_email")
 		utils.RespondWithJSON(w, 400, map[string]any{
 			"type": "error",
 			"msg":  "Invalid email or password",
@@ -34,6 +102,7 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	err = utils.CheckPasswordHash(credentials.Password, user.Password)
 	if err != nil {
 		log.Println("Error from utils.CheckPasswordHash function.", err)
+		recordLoginFailure(r, user.ID, credentials.Email, "wrong_password")
 		utils.RespondWithJSON(w, 400, map[string]any{
 			"type": "error",
 			"msg":  "Invalid email or password",
@@ -41,31 +110,47 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jti, accessTokenStr, refreshTokenStr, err := utils.IssueJWT(user.ID)
-	if err != nil {
-		utils.RespondWith500Error(w)
+	rehashPasswordIfNeeded(ctx, db, user, credentials.Password)
+
+	if user.TotpEnabled {
+		// The attempt is counted again when the code is entered
+		err = loginLimiter.Release(ctx, credentials.Email, ip)
+		if err != nil {
+			log.Println("Error from loginLimiter.Release method.", err)
+		}
+
+		challengeTokenStr, err := utils.IssueChallengeToken(user.ID)
+		if err != nil {
+			utils.RespondWith500Error(w)
+			return
+		}
+
+		utils.RespondWithJSON(w, 200, map[string]any{
+			"type":            "two_factor_required",
+			"msg":             "Please enter the code from your authenticator app",
+			"challenge_token": challengeTokenStr,
+		})
 		return
 	}
 
-	err = db.SetActiveToken(ctx, database.SetActiveTokenParams{
-		Sub: user.ID,
-		Jti: jti,
-	})
+	err = loginLimiter.RecordSuccess(ctx, credentials.Email, ip)
+	if err != nil {
+		log.Println("Error from loginLimiter.RecordSuccess method.", err)
+	}
+
+	accessTokenStr, refreshTokenStr, err := createSession(ctx, db, r, user.ID, user.Role, credentials.DeviceName)
 	if err != nil {
-		log.Println("Error from db.SetActiveToken method.", err)
 		utils.RespondWith500Error(w)
 		return
 	}
+	utils.RecordAuditEvent(ctx, db, r, user.ID, utils.AUDIT_LOGIN, map[string]any{
+		"two_factor":  false,
+		"device_name": credentials.DeviceName,
+	})
 
 	utils.RespondWithJSON(w, 200, map[string]any{
-		"type": "success",
-		"user": map[string]any{
-			"id":      user.ID,
-			"name":    user.Name,
-			"email":   user.Email,
-			"points":  user.Points,
-			"credits": user.Credits,
-		},
+		"type":          "success",
+		"user":          userToMap(user),
 		"access_token":  accessTokenStr,
 		"refresh_token": refreshTokenStr,
 	})
@@ -140,19 +225,15 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jti, accessTokenStr, refreshTokenStr, err := utils.IssueJWT(user.ID)
+	accessTokenStr, refreshTokenStr, err := createSession(ctx, qtx, r, user.ID, user.Role, data.DeviceName)
 	if err != nil {
 		tx.Rollback()
 		utils.RespondWith500Error(w)
 		return
 	}
 
-	err = qtx.SetActiveToken(ctx, database.SetActiveTokenParams{
-		Sub: user.ID,
-		Jti: jti,
-	})
+	verificationToken, err := createEmailVerificationToken(ctx, qtx, user.ID)
 	if err != nil {
-		log.Println("Error from qtx.SetActiveToken method.", err)
 		tx.Rollback()
 		utils.RespondWith500Error(w)
 		return
@@ -165,15 +246,10 @@ func Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	sendEmailVerification(user.Name, user.Email, verificationToken)
 	utils.RespondWithJSON(w, 201, map[string]any{
-		"type": "success",
-		"user": map[string]any{
-			"id":      user.ID,
-			"name":    user.Name,
-			"email":   user.Email,
-			"points":  user.Points,
-			"credits": user.Credits,
-		},
+		"type":          "success",
+		"user":          userToMap(user),
 		"access_token":  accessTokenStr,
 		"refresh_token": refreshTokenStr,
 	})
@@ -203,3 +279,121 @@ func GetUserPointsAndCredits(w http.ResponseWriter, r *http.Request) {
 		"credits": user.Credits,
 	})
 }
+
+func ChangePassword(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	_, token, _ := utils.JWTAuthFromContext(ctx)
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.ChangePasswordPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	err = utils.CheckPasswordHash(data.CurrentPassword, user.Password)
+	if err != nil {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"current_password": "Current password is incorrect",
+			},
+		})
+		return
+	}
+
+	hashedPassword, err := utils.HashPassword(data.NewPassword)
+	if err != nil {
+		log.Println("Error hashing password.", err)
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "validation_error",
+			"msg": map[string]any{
+				"new_password": "Bad password",
+			},
+		})
+		return
+	}
+
+	sessionId, err := db.GetActiveTokenId(ctx, database.GetActiveTokenIdParams{
+		Sub: userId,
+		Jti: token.JwtID(),
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetActiveTokenId method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+		ID:        userId,
+		Password:  hashedPassword,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.UpdateUserPassword method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.DeleteOtherActiveTokens(ctx, database.DeleteOtherActiveTokensParams{
+		Sub: userId,
+		ID:  sessionId,
+	})
+	if err != nil {
+		log.Println("Error from qtx.DeleteOtherActiveTokens method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	accessTokenStr, refreshTokenStr, err := rotateSession(ctx, qtx, r, userId, user.Role, sessionId, token.JwtID())
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	utils.InvalidateActiveTokens(userId)
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type":          "success",
+		"msg":           "Your password has been changed",
+		"access_token":  accessTokenStr,
+		"refresh_token": refreshTokenStr,
+	})
+}
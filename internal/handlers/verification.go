@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/mailer"
+	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+const emailVerificationTokenLifetime = time.Hour * 24
+
+func createEmailVerificationToken(ctx context.Context, q *database.Queries, userId int32) (string, error) {
+	token, tokenHash, err := utils.GenerateToken()
+	if err != nil {
+		log.Println("Error generating an email verification token.", err)
+		return "", err
+	}
+
+	err = q.CreateEmailVerificationToken(ctx, database.CreateEmailVerificationTokenParams{
+		UserID:    userId,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(emailVerificationTokenLifetime),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateEmailVerificationToken method.", err)
+		return "", err
+	}
+	return token, nil
+}
+
+func sendEmailVerification(name string, email string, token string) {
+	body := fmt.Sprintf(
+		"Hi %s,\n\nUse the following code to verify your email address:\n\n%s\n\n"+
+			"The code expires in 24 hours.",
+		name, token,
+	)
+	go func() {
+		err := mailer.GetMailer().Send(email, "Verify your email address", body)
+		if err != nil {
+			log.Println("Error sending the email verification email.", err)
+		}
+	}()
+}
+
+func VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	data, ok := ctx.Value(utils.VALIDATED_CTX).(*middlewares.VerifyEmailPayload)
+	if !ok {
+		log.Println("Error casting the validated request payload")
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	invalidTokenResponse := map[string]any{
+		"type": "validation_error",
+		"msg": map[string]any{
+			"token": "The verification token is invalid or has expired",
+		},
+	}
+
+	verificationToken, err := db.GetEmailVerificationToken(ctx, database.GetEmailVerificationTokenParams{
+		TokenHash: utils.HashToken(data.Token),
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetEmailVerificationToken method.", err)
+			utils.RespondWith500Error(w)
+			return
+		}
+		utils.RespondWithJSON(w, 400, invalidTokenResponse)
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	count, err := qtx.UseEmailVerificationToken(ctx, database.UseEmailVerificationTokenParams{
+		ID:     verificationToken.ID,
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.UseEmailVerificationToken method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+	if count == 0 {
+		tx.Rollback()
+		utils.RespondWithJSON(w, 400, invalidTokenResponse)
+		return
+	}
+
+	err = qtx.VerifyUserEmail(ctx, database.VerifyUserEmailParams{
+		ID:         verificationToken.UserID,
+		VerifiedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		UpdatedAt:  time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from qtx.VerifyUserEmail method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = qtx.InvalidateEmailVerificationTokens(ctx, database.InvalidateEmailVerificationTokensParams{
+		UserID: verificationToken.UserID,
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.InvalidateEmailVerificationTokens method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "Your email has been verified",
+	})
+}
+
+func ResendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	db := database.GetDB()
+	ctx := r.Context()
+	userId := utils.GetTokenSubject(ctx)
+	if userId == 0 {
+		utils.RespondWith401Error(w)
+		return
+	}
+
+	user, err := db.GetUserById(ctx, userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error from db.GetUserById method.", err)
+		}
+		utils.RespondWith401Error(w)
+		return
+	}
+	if user.VerifiedAt.Valid {
+		utils.RespondWithJSON(w, 400, map[string]any{
+			"type": "error",
+			"msg":  "Your email has already been verified",
+		})
+		return
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		log.Println("Error starting a transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+	qtx := db.WithTx(tx)
+
+	err = qtx.InvalidateEmailVerificationTokens(ctx, database.InvalidateEmailVerificationTokensParams{
+		UserID: userId,
+		UsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		log.Println("Error from qtx.InvalidateEmailVerificationTokens method.", err)
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	token, err := createEmailVerificationToken(ctx, qtx, userId)
+	if err != nil {
+		tx.Rollback()
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Println("Error commiting the transaction.", err)
+		utils.RespondWith500Error(w)
+		return
+	}
+
+	sendEmailVerification(user.Name, user.Email, token)
+	utils.RespondWithJSON(w, 200, map[string]any{
+		"type": "success",
+		"msg":  "A new verification code has been sent to your email",
+	})
+}
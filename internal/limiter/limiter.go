@@ -0,0 +1,233 @@
+package limiter
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Attempt is the failure history kept for a single key.
+// The zero value means that no failure has been recorded.
+type Attempt struct {
+	Failures     int32
+	LastFailedAt time.Time
+	LockedUntil  time.Time
+}
+
+type Store interface {
+	// Update replaces the attempt of the key with the result of update.
+	// Reading and writing the attempt is atomic, so concurrent updates
+	// of the same key cannot overwrite each other.
+	Update(ctx context.Context, key string, update func(Attempt) Attempt) error
+	Delete(ctx context.Context, key string) error
+	// Prune removes the attempts that failed last before the given time
+	// and are not locked anymore.
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// Policy describes how failures are punished.
+// After FreeAttempts failures every new attempt has to wait BaseDelay,
+// doubled for each further failure up to MaxDelay.
+// After LockoutAfter failures the key is locked for LockoutDuration.
+// The failures are forgotten after ResetAfter without a new failure.
+type Policy struct {
+	FreeAttempts    int32
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutAfter    int32
+	LockoutDuration time.Duration
+	ResetAfter      time.Duration
+}
+
+// retryAfter returns how long the key has to wait before the next attempt.
+func (p Policy) retryAfter(attempt Attempt, now time.Time) time.Duration {
+	if now.Before(attempt.LockedUntil) {
+		return attempt.LockedUntil.Sub(now)
+	}
+	if now.Sub(attempt.LastFailedAt) > p.ResetAfter || attempt.Failures <= p.FreeAttempts {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < attempt.Failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	nextAttemptAt := attempt.LastFailedAt.Add(delay)
+	if now.Before(nextAttemptAt) {
+		return nextAttemptAt.Sub(now)
+	}
+	return 0
+}
+
+func (p Policy) recordFailure(attempt Attempt, now time.Time) Attempt {
+	if now.Sub(attempt.LastFailedAt) > p.ResetAfter {
+		attempt = Attempt{}
+	}
+
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	if attempt.Failures >= p.LockoutAfter {
+		attempt.LockedUntil = now.Add(p.LockoutDuration)
+	}
+	return attempt
+}
+
+// releaseFailure takes back a failure recorded for an attempt that did not fail.
+// A lockout is lifted if it was only caused by that attempt.
+func (p Policy) releaseFailure(attempt Attempt) Attempt {
+	if attempt.Failures > 0 {
+		attempt.Failures--
+	}
+	if attempt.Failures < p.LockoutAfter {
+		attempt.LockedUntil = time.Time{}
+	}
+	return attempt
+}
+
+// LoginLimiter tracks failed logins per email and per IP address.
+// The IP policy is looser because many users can share one address.
+type LoginLimiter struct {
+	store       Store
+	emailPolicy Policy
+	ipPolicy    Policy
+}
+
+func NewLoginLimiter(store Store, emailPolicy Policy, ipPolicy Policy) *LoginLimiter {
+	return &LoginLimiter{
+		store:       store,
+		emailPolicy: emailPolicy,
+		ipPolicy:    ipPolicy,
+	}
+}
+
+func emailKey(email string) string {
+	return "email:" + strings.ToLower(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// reserve counts an attempt as a failure unless the key has to wait.
+// It returns how long the key has to wait, or 0 if the attempt was counted.
+func (l *LoginLimiter) reserve(ctx context.Context, key string, policy Policy, now time.Time) (time.Duration, error) {
+	var retryAfter time.Duration
+	err := l.store.Update(ctx, key, func(attempt Attempt) Attempt {
+		retryAfter = policy.retryAfter(attempt, now)
+		if retryAfter > 0 {
+			return attempt
+		}
+		return policy.recordFailure(attempt, now)
+	})
+	return retryAfter, err
+}
+
+func (l *LoginLimiter) release(ctx context.Context, key string, policy Policy) error {
+	return l.store.Update(ctx, key, policy.releaseFailure)
+}
+
+// Check returns how long the client has to wait before it may try to log in
+// with the given email, or 0 if it may try now.
+// An attempt that may go ahead is counted as a failure right away, so parallel
+// attempts cannot get past the limits while their credentials are checked.
+// Call RecordSuccess or Release if the attempt turns out not to be a failure.
+func (l *LoginLimiter) Check(ctx context.Context, email string, ip string) (time.Duration, error) {
+	now := time.Now()
+
+	retryAfter, err := l.reserve(ctx, emailKey(email), l.emailPolicy, now)
+	if err != nil || retryAfter > 0 {
+		return retryAfter, err
+	}
+
+	retryAfter, err = l.reserve(ctx, ipKey(ip), l.ipPolicy, now)
+	if err != nil || retryAfter > 0 {
+		if releaseErr := l.release(ctx, emailKey(email), l.emailPolicy); releaseErr != nil {
+			log.Println("Error releasing the login attempt.", releaseErr)
+		}
+		return retryAfter, err
+	}
+	return 0, nil
+}
+
+// Release takes back an attempt counted by Check that neither failed nor
+// completed the login, such as a correct password that still needs a second factor.
+func (l *LoginLimiter) Release(ctx context.Context, email string, ip string) error {
+	err := l.release(ctx, emailKey(email), l.emailPolicy)
+	if err != nil {
+		return err
+	}
+	return l.release(ctx, ipKey(ip), l.ipPolicy)
+}
+
+// RecordSuccess forgets the failures of the email and takes back the attempt
+// counted for the IP address by Check.
+// The earlier failures of the IP address are kept, otherwise an attacker could
+// reset them by logging in to an account of their own from time to time.
+func (l *LoginLimiter) RecordSuccess(ctx context.Context, email string, ip string) error {
+	err := l.store.Delete(ctx, emailKey(email))
+	if err != nil {
+		return err
+	}
+	return l.release(ctx, ipKey(ip), l.ipPolicy)
+}
+
+func (l *LoginLimiter) prune(ctx context.Context) error {
+	resetAfter := l.emailPolicy.ResetAfter
+	if l.ipPolicy.ResetAfter > resetAfter {
+		resetAfter = l.ipPolicy.ResetAfter
+	}
+	return l.store.Prune(ctx, time.Now().Add(-resetAfter))
+}
+
+var loginLimiter *LoginLimiter
+
+var defaultEmailPolicy = Policy{
+	FreeAttempts:    3,
+	BaseDelay:       time.Second,
+	MaxDelay:        5 * time.Minute,
+	LockoutAfter:    10,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+var defaultIPPolicy = Policy{
+	FreeAttempts:    20,
+	BaseDelay:       time.Second,
+	MaxDelay:        5 * time.Minute,
+	LockoutAfter:    100,
+	LockoutDuration: 15 * time.Minute,
+	ResetAfter:      time.Hour,
+}
+
+const pruneInterval = 10 * time.Minute
+
+func UseLoginLimiter() {
+	var store Store
+	switch os.Getenv("LOGIN_LIMITER_STORE") {
+	case "", "memory":
+		store = NewMemoryStore()
+	case "mysql":
+		store = NewMySQLStore()
+	default:
+		log.Fatalln("Unknown LOGIN_LIMITER_STORE " + os.Getenv("LOGIN_LIMITER_STORE") + ".")
+	}
+	loginLimiter = NewLoginLimiter(store, defaultEmailPolicy, defaultIPPolicy)
+
+	go func() {
+		for range time.Tick(pruneInterval) {
+			if err := loginLimiter.prune(context.Background()); err != nil {
+				log.Println("Error pruning the login attempts.", err)
+			}
+		}
+	}()
+}
+
+func GetLoginLimiter() *LoginLimiter {
+	return loginLimiter
+}
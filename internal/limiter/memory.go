@@ -0,0 +1,45 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the attempts in the memory of the current process.
+// Use MySQLStore when the server runs on more than one instance.
+type MemoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]Attempt
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{attempts: make(map[string]Attempt)}
+}
+
+func (s *MemoryStore) Update(ctx context.Context, key string, update func(Attempt) Attempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts[key] = update(s.attempts[key])
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+func (s *MemoryStore) Prune(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, attempt := range s.attempts {
+		if attempt.LastFailedAt.Before(before) && !now.Before(attempt.LockedUntil) {
+			delete(s.attempts, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package limiter
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// MySQLStore keeps the attempts in the login_attempts table,
+// so they are shared by every instance of the server.
+type MySQLStore struct{}
+
+func NewMySQLStore() *MySQLStore {
+	return &MySQLStore{}
+}
+
+func (s *MySQLStore) Update(ctx context.Context, key string, update func(Attempt) Attempt) error {
+	db := database.GetDB()
+	now := time.Now()
+
+	// The row is created first, so that SELECT ... FOR UPDATE locks it.
+	// A missing row would only lock a gap, which lets concurrent
+	// transactions read it as missing and insert it twice.
+	err := db.CreateLoginAttempt(ctx, database.CreateLoginAttemptParams{
+		AttemptKey:   key,
+		LastFailedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	conn := database.GetDBConn()
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+	qtx := db.WithTx(tx)
+
+	var attempt Attempt
+	loginAttempt, err := qtx.GetLoginAttemptForUpdate(ctx, key)
+	if err == nil {
+		attempt = Attempt{
+			Failures:     loginAttempt.Failures,
+			LastFailedAt: loginAttempt.LastFailedAt,
+			LockedUntil:  loginAttempt.LockedUntil.Time,
+		}
+	} else if err != sql.ErrNoRows {
+		// The row can only be missing if it was pruned in the meantime
+		tx.Rollback()
+		return err
+	}
+
+	attempt = update(attempt)
+	err = qtx.SetLoginAttempt(ctx, database.SetLoginAttemptParams{
+		AttemptKey:   key,
+		Failures:     attempt.Failures,
+		LastFailedAt: attempt.LastFailedAt,
+		LockedUntil: sql.NullTime{
+			Time:  attempt.LockedUntil,
+			Valid: !attempt.LockedUntil.IsZero(),
+		},
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *MySQLStore) Delete(ctx context.Context, key string) error {
+	return database.GetDB().DeleteLoginAttempt(ctx, key)
+}
+
+func (s *MySQLStore) Prune(ctx context.Context, before time.Time) error {
+	return database.GetDB().DeleteStaleLoginAttempts(ctx, database.DeleteStaleLoginAttemptsParams{
+		LastFailedAt: before,
+		LockedUntil:  sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+type Mailer interface {
+	Send(to string, subject string, body string) error
+}
+
+var mailer Mailer
+
+func UseMailer() {
+	switch os.Getenv("MAILER") {
+	case "smtp":
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			log.Fatalln("Error parsing SMTP_PORT.", err)
+		}
+		mailer = NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			port,
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("MAIL_FROM"),
+		)
+	case "", "file":
+		mailer = NewFileMailer(os.Getenv("MAILER_FILE"))
+	default:
+		log.Fatalln("Unknown MAILER " + os.Getenv("MAILER") + ".")
+	}
+}
+
+func GetMailer() Mailer {
+	return mailer
+}
@@ -2,10 +2,15 @@ package middlewares
 
 import (
 	"context"
+	"database/sql"
+	"log"
 	"net/http"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/vuezy/go-ask-and-answer/internal/database"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
 
@@ -19,7 +24,7 @@ func VerifyAccessToken(next http.Handler) http.Handler {
 			return
 		}
 
-		token, claims, code := utils.VerifyToken(ctx, tokenStr, false, nil)
+		token, claims, code := utils.VerifyToken(r, tokenStr, false, nil)
 		if code == 401 {
 			utils.RespondWith401Error(w)
 			return
@@ -35,6 +40,49 @@ func VerifyAccessToken(next http.Handler) http.Handler {
 	})
 }
 
+const apiKeyTouchInterval = time.Minute
+
+// VerifyAccessTokenOrAPIKey accepts either an access token or a personal API key
+// in the Authorization header. Requests made with an API key are limited by RequireScope.
+func VerifyAccessTokenOrAPIKey(next http.Handler) http.Handler {
+	verifyAccessToken := VerifyAccessToken(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenStr := utils.ExtractTokenFromHeader(r)
+		if !utils.IsAPIKey(tokenStr) {
+			verifyAccessToken.ServeHTTP(w, r)
+			return
+		}
+
+		db := database.GetDB()
+		ctx := r.Context()
+
+		apiKey, err := db.GetApiKeyByHash(ctx, utils.HashToken(tokenStr))
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Println("Error from db.GetApiKeyByHash method.", err)
+				utils.RespondWith500Error(w)
+				return
+			}
+			utils.RespondWith401Error(w)
+			return
+		}
+
+		// last_used_at does not need to be exact, so it is not written on every request
+		if !apiKey.LastUsedAt.Valid || time.Since(apiKey.LastUsedAt.Time) > apiKeyTouchInterval {
+			err = db.TouchApiKey(ctx, database.TouchApiKeyParams{
+				LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				ID:         apiKey.ID,
+			})
+			if err != nil {
+				log.Println("Error from db.TouchApiKey method.", err)
+			}
+		}
+
+		ctx = context.WithValue(ctx, utils.API_KEY_CTX, apiKey)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func VerifyRefreshToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -45,7 +93,7 @@ func VerifyRefreshToken(next http.Handler) http.Handler {
 			return
 		}
 
-		refreshToken, refreshTokenClaims, code := utils.VerifyToken(ctx, refreshTokenStr, true, nil)
+		refreshToken, refreshTokenClaims, code := utils.VerifyToken(r, refreshTokenStr, true, nil)
 		if code == 401 {
 			utils.AskToReauthenticate(w)
 			return
@@ -75,7 +123,7 @@ func VerifyRefreshToken(next http.Handler) http.Handler {
 		clock := jwt.ClockFunc(func() time.Time {
 			return time.Time{}
 		})
-		accessToken, _, code := utils.VerifyToken(ctx, accessTokenStr, false, clock)
+		accessToken, _, code := utils.VerifyToken(r, accessTokenStr, false, clock)
 		if code == 401 {
 			utils.AskToReauthenticate(w)
 			return
@@ -90,3 +138,74 @@ func VerifyRefreshToken(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequireVerifiedEmail blocks users who have not verified their email address
+// if REQUIRE_EMAIL_VERIFICATION is set to true. It must run after VerifyAccessToken.
+func RequireVerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if os.Getenv("REQUIRE_EMAIL_VERIFICATION") != "true" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		db := database.GetDB()
+		ctx := r.Context()
+		userId := utils.GetTokenSubject(ctx)
+		if userId == 0 {
+			utils.RespondWith401Error(w)
+			return
+		}
+
+		user, err := db.GetUserById(ctx, userId)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Println("Error from db.GetUserById method.", err)
+				utils.RespondWith500Error(w)
+				return
+			}
+			utils.RespondWith401Error(w)
+			return
+		}
+		if !user.VerifiedAt.Valid {
+			utils.RespondWithJSON(w, 403, map[string]any{
+				"type": "verification_error",
+				"msg":  "Please verify your email address to do this action",
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole blocks users whose token does not carry one of the given roles.
+// It must run after VerifyAccessToken.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !slices.Contains(roles, utils.GetTokenRole(r.Context())) {
+				utils.RespondWith403Error(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope blocks requests made with an API key that has not been granted the scope.
+// Requests made with an access token are not limited by scopes.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey, ok := utils.APIKeyFromContext(r.Context())
+			if ok && !utils.HasScope(apiKey, scope) {
+				utils.RespondWithJSON(w, 403, map[string]any{
+					"type": "error",
+					"msg":  "The API key does not have the " + scope + " scope",
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
+	"github.com/vuezy/go-ask-and-answer/internal/passwords"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
 
@@ -25,14 +28,31 @@ func init() {
 		}
 		return match
 	})
+
+	validate.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return passwords.GetPasswordPolicy().Check(fl.Field().String()) == ""
+	})
+
+	validate.RegisterValidation("tagname", func(fl validator.FieldLevel) bool {
+		_, valid := utils.NormalizeTag(fl.Field().String())
+		return valid
+	})
 }
 
 type payload interface {
-	*LoginPayload | *RegisterPayload | *QuestionPayload | *AnswerPayload
+	*LoginPayload | *RegisterPayload | *ChangePasswordPayload |
+		*ForgotPasswordPayload | *ResetPasswordPayload | *VerifyEmailPayload |
+		*TwoFactorCodePayload | *TwoFactorLoginPayload | *RolePayload |
+		*ProfilePayload | *DeleteAccountPayload |
+		*QuestionPayload | *AnswerPayload | *APIKeyPayload
 }
 
 func performValidation[T payload](next http.Handler, payload T, setErrMsg func(map[string]any, string)) http.Handler {
+	payloadType := reflect.TypeOf(payload).Elem()
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every request is decoded into a new payload, otherwise fields left out of
+		// the request body would keep the values of a previous request
+		payload := reflect.New(payloadType).Interface().(T)
 		if err := utils.ParseJSON(w, r.Body, payload); err != nil {
 			return
 		}
@@ -48,6 +68,11 @@ func performValidation[T payload](next http.Handler, payload T, setErrMsg func(m
 				if _, exists := msg[err.Field()]; exists {
 					continue
 				}
+				// The password policy explains itself, so its message is used as is
+				if err.Tag() == "password" {
+					msg[jsonFieldName(payload, err.StructField())] = passwords.GetPasswordPolicy().Check(err.Value().(string))
+					continue
+				}
 				setErrMsg(msg, err.Field())
 			}
 			utils.RespondWithJSON(w, 400, response)
@@ -59,6 +84,12 @@ func performValidation[T payload](next http.Handler, payload T, setErrMsg func(m
 	})
 }
 
+func jsonFieldName(payload any, structField string) string {
+	field, _ := reflect.TypeOf(payload).Elem().FieldByName(structField)
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name
+}
+
 func ParseIdFromURLParam(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idStr := chi.URLParam(r, "id")
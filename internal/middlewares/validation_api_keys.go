@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+)
+
+type APIKeyPayload struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Scopes []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=questions:read questions:write answers:read answers:write votes:write"`
+}
+
+func ValidateAPIKeyPayload(next http.Handler) http.Handler {
+	return performValidation(next, &APIKeyPayload{}, func(msg map[string]any, field string) {
+		if field == "Name" {
+			msg["name"] = "Name is required (max length: 50)"
+		} else if strings.HasPrefix(field, "Scopes") {
+			msg["scopes"] = "Scopes must be a list of distinct values from: " +
+				"questions:read, questions:write, answers:read, answers:write, votes:write"
+		}
+	})
+}
@@ -2,12 +2,14 @@ package middlewares
 
 import (
 	"net/http"
+	"strings"
 )
 
 type QuestionPayload struct {
-	Title         string `json:"title" validate:"required,min=1,max=50"`
-	Body          string `json:"body" validate:"required,min=1,max=300"`
-	PriorityLevel int32  `json:"priority_level" validate:"omitnil,numeric,gte=0"`
+	Title         string   `json:"title" validate:"required,min=1,max=50"`
+	Body          string   `json:"body" validate:"required,min=1,max=300"`
+	PriorityLevel int32    `json:"priority_level" validate:"omitnil,numeric,gte=0"`
+	Tags          []string `json:"tags" validate:"omitempty,max=5,dive,tagname"`
 }
 
 func ValidateQuestionPayload(next http.Handler) http.Handler {
@@ -18,6 +20,8 @@ func ValidateQuestionPayload(next http.Handler) http.Handler {
 			msg["body"] = "Body is required (max length: 300)"
 		} else if field == "PriorityLevel" {
 			msg["priority_level"] = "Priority level must be a number not greater than your credits"
+		} else if strings.HasPrefix(field, "Tags") {
+			msg["tags"] = "Up to 5 tags, each 1-30 letters, digits or the characters + # . - (starting with a letter or digit)"
 		}
 	})
 }
@@ -5,14 +5,60 @@ import (
 )
 
 type LoginPayload struct {
-	Email    string `json:"email" validate:"required,email,max=50"`
-	Password string `json:"password" validate:"required,alphanum,min=8,max=32"`
+	Email      string `json:"email" validate:"required,email,max=50"`
+	Password   string `json:"password" validate:"required,max=72"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=50"`
 }
 
+// The password tag checks new passwords against the password policy.
 type RegisterPayload struct {
-	Name     string `json:"name" validate:"required,alphaspace,min=3,max=20"`
-	Email    string `json:"email" validate:"required,email,max=50"`
-	Password string `json:"password" validate:"required,alphanum,min=8,max=32"`
+	Name       string `json:"name" validate:"required,alphaspace,min=3,max=20"`
+	Email      string `json:"email" validate:"required,email,max=50"`
+	Password   string `json:"password" validate:"required,password"`
+	DeviceName string `json:"device_name" validate:"omitempty,max=50"`
+}
+
+type ChangePasswordPayload struct {
+	CurrentPassword string `json:"current_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password" validate:"required,password,nefield=CurrentPassword"`
+}
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email,max=50"`
+}
+
+type ResetPasswordPayload struct {
+	Token    string `json:"token" validate:"required,len=64,hexadecimal"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+type VerifyEmailPayload struct {
+	Token string `json:"token" validate:"required,len=64,hexadecimal"`
+}
+
+type TwoFactorCodePayload struct {
+	Code string `json:"code" validate:"required,min=6,max=11"`
+}
+
+type TwoFactorLoginPayload struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required,min=6,max=11"`
+	DeviceName     string `json:"device_name" validate:"omitempty,max=50"`
+}
+
+type RolePayload struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
+}
+
+// ProfilePayload only changes the fields that are present in the request.
+type ProfilePayload struct {
+	Name *string `json:"name" validate:"omitnil,alphaspace,min=3,max=20"`
+	Bio  *string `json:"bio" validate:"omitnil,max=160"`
+}
+
+type DeleteAccountPayload struct {
+	Password string `json:"password" validate:"required,max=72"`
+	Code     string `json:"code" validate:"omitempty,min=6,max=11"`
 }
 
 func ValidateLoginPayload(next http.Handler) http.Handler {
@@ -20,7 +66,9 @@ func ValidateLoginPayload(next http.Handler) http.Handler {
 		if field == "Email" {
 			msg["email"] = "Email must be valid (max length: 50)"
 		} else if field == "Password" {
-			msg["password"] = "Password must contain 8-32 alphanumeric characters"
+			msg["password"] = "Password is required (max length: 72)"
+		} else if field == "DeviceName" {
+			msg["device_name"] = "Device name must not exceed 50 characters"
 		}
 	})
 }
@@ -32,7 +80,93 @@ func ValidateRegisterPayload(next http.Handler) http.Handler {
 		} else if field == "Email" {
 			msg["email"] = "Email must be valid (max length: 50)"
 		} else if field == "Password" {
-			msg["password"] = "Password must contain 8-32 alphanumeric characters"
+			msg["password"] = "Password is required"
+		} else if field == "DeviceName" {
+			msg["device_name"] = "Device name must not exceed 50 characters"
+		}
+	})
+}
+
+func ValidateChangePasswordPayload(next http.Handler) http.Handler {
+	return performValidation(next, &ChangePasswordPayload{}, func(msg map[string]any, field string) {
+		if field == "CurrentPassword" {
+			msg["current_password"] = "Current password is required (max length: 72)"
+		} else if field == "NewPassword" {
+			msg["new_password"] = "New password is required and must differ from the current one"
+		}
+	})
+}
+
+func ValidateForgotPasswordPayload(next http.Handler) http.Handler {
+	return performValidation(next, &ForgotPasswordPayload{}, func(msg map[string]any, field string) {
+		if field == "Email" {
+			msg["email"] = "Email must be valid (max length: 50)"
+		}
+	})
+}
+
+func ValidateResetPasswordPayload(next http.Handler) http.Handler {
+	return performValidation(next, &ResetPasswordPayload{}, func(msg map[string]any, field string) {
+		if field == "Token" {
+			msg["token"] = "The reset token is invalid or has expired"
+		} else if field == "Password" {
+			msg["password"] = "Password is required"
+		}
+	})
+}
+
+func ValidateVerifyEmailPayload(next http.Handler) http.Handler {
+	return performValidation(next, &VerifyEmailPayload{}, func(msg map[string]any, field string) {
+		if field == "Token" {
+			msg["token"] = "The verification token is invalid or has expired"
+		}
+	})
+}
+
+func ValidateTwoFactorCodePayload(next http.Handler) http.Handler {
+	return performValidation(next, &TwoFactorCodePayload{}, func(msg map[string]any, field string) {
+		if field == "Code" {
+			msg["code"] = "Code must be a 6-digit code or a recovery code"
+		}
+	})
+}
+
+func ValidateTwoFactorLoginPayload(next http.Handler) http.Handler {
+	return performValidation(next, &TwoFactorLoginPayload{}, func(msg map[string]any, field string) {
+		if field == "ChallengeToken" {
+			msg["challenge_token"] = "The challenge token is invalid or has expired"
+		} else if field == "Code" {
+			msg["code"] = "Code must be a 6-digit code or a recovery code"
+		} else if field == "DeviceName" {
+			msg["device_name"] = "Device name must not exceed 50 characters"
+		}
+	})
+}
+
+func ValidateRolePayload(next http.Handler) http.Handler {
+	return performValidation(next, &RolePayload{}, func(msg map[string]any, field string) {
+		if field == "Role" {
+			msg["role"] = "Role must be one of user, moderator or admin"
+		}
+	})
+}
+
+func ValidateProfilePayload(next http.Handler) http.Handler {
+	return performValidation(next, &ProfilePayload{}, func(msg map[string]any, field string) {
+		if field == "Name" {
+			msg["name"] = "Name must contain 3-20 alphabet characters"
+		} else if field == "Bio" {
+			msg["bio"] = "Bio must not exceed 160 characters"
+		}
+	})
+}
+
+func ValidateDeleteAccountPayload(next http.Handler) http.Handler {
+	return performValidation(next, &DeleteAccountPayload{}, func(msg map[string]any, field string) {
+		if field == "Password" {
+			msg["password"] = "Password is required (max length: 72)"
+		} else if field == "Code" {
+			msg["code"] = "Code must be a 6-digit code or a recovery code"
 		}
 	})
 }
@@ -0,0 +1,63 @@
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BreachedRule rejects passwords found in a local copy of a breached password list.
+// The list is split by k-anonymity range like the Pwned Passwords API:
+// the file <dir>/<first 5 hex characters of the SHA-1>.txt contains one line
+// per breached password, with the remaining 35 characters of the hash and
+// the number of times it has been seen ("SUFFIX:COUNT").
+type BreachedRule struct {
+	dir string
+}
+
+func NewBreachedRule(dir string) BreachedRule {
+	return BreachedRule{dir: dir}
+}
+
+func (r BreachedRule) Check(password string) string {
+	breached, err := r.isBreached(password)
+	if err != nil {
+		// A broken list should not prevent users from setting a password
+		log.Println("Error checking the breached password list.", err)
+		return ""
+	}
+	if breached {
+		return "This password has appeared in a data breach, please choose another one"
+	}
+	return ""
+}
+
+func (r BreachedRule) isBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	file, err := os.Open(filepath.Join(r.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineSuffix, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(lineSuffix, suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,81 @@
+package passwords
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"unicode/utf8"
+
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
+)
+
+// bcrypt does not accept passwords longer than 72 bytes
+const maxPasswordBytes = 72
+
+// Rule is a single requirement of the password policy.
+type Rule interface {
+	// Check returns a message explaining why the password is rejected,
+	// or an empty string if it is accepted.
+	Check(password string) string
+}
+
+// Policy rejects a password as soon as one of its rules rejects it.
+type Policy struct {
+	rules []Rule
+}
+
+func NewPolicy(rules ...Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+func (p *Policy) Check(password string) string {
+	for _, rule := range p.rules {
+		if msg := rule.Check(password); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// LengthRule only limits the number of characters, any character is allowed.
+type LengthRule struct {
+	Min int
+	Max int
+}
+
+func (r LengthRule) Check(password string) string {
+	length := utf8.RuneCountInString(password)
+	if length < r.Min || length > r.Max || len(password) > maxPasswordBytes {
+		return fmt.Sprintf("Password must contain %d-%d characters", r.Min, r.Max)
+	}
+	return ""
+}
+
+var policy = NewPolicy(LengthRule{Min: 8, Max: 64})
+
+// UsePasswordPolicy builds the policy used for new passwords.
+// The breached password check is only enabled when BREACHED_PASSWORDS_DIR is set.
+func UsePasswordPolicy() {
+	lengthRule := LengthRule{
+		Min: utils.GetIntEnv("PASSWORD_MIN_LENGTH", 8),
+		Max: utils.GetIntEnv("PASSWORD_MAX_LENGTH", 64),
+	}
+	if lengthRule.Min < 1 || lengthRule.Max < lengthRule.Min || lengthRule.Max > maxPasswordBytes {
+		log.Fatalln("PASSWORD_MIN_LENGTH and PASSWORD_MAX_LENGTH must be between 1 and", maxPasswordBytes)
+	}
+	rules := []Rule{lengthRule}
+
+	if dir := os.Getenv("BREACHED_PASSWORDS_DIR"); dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			log.Fatalln("BREACHED_PASSWORDS_DIR must be a directory.", err)
+		}
+		rules = append(rules, NewBreachedRule(dir))
+	}
+
+	policy = NewPolicy(rules...)
+}
+
+func GetPasswordPolicy() *Policy {
+	return policy
+}
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Maximum number of characters of a snippet
+const snippetLength = 160
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordSpans returns the start and end indexes of the words in the text.
+func wordSpans(runes []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range runes {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(runes)})
+	}
+	return spans
+}
+
+// Tokenize splits the text into lowercase words.
+func Tokenize(text string) []string {
+	runes := []rune(text)
+	spans := wordSpans(runes)
+	tokens := make([]string, 0, len(spans))
+	for _, span := range spans {
+		tokens = append(tokens, strings.ToLower(string(runes[span[0]:span[1]])))
+	}
+	return tokens
+}
+
+// Terms returns the distinct words of the query.
+func Terms(query string) map[string]bool {
+	terms := make(map[string]bool)
+	for _, token := range Tokenize(query) {
+		terms[token] = true
+	}
+	return terms
+}
+
+func containsTerm(text string, terms map[string]bool) bool {
+	for _, token := range Tokenize(text) {
+		if terms[token] {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight escapes the text for HTML and wraps the words found in terms in <mark> tags.
+// A text longer than maxLength characters is cut around the first match.
+func Highlight(text string, terms map[string]bool, maxLength int) string {
+	runes := []rune(text)
+	spans := wordSpans(runes)
+
+	from, to := 0, len(runes)
+	if len(runes) > maxLength {
+		firstMatch := 0
+		for _, span := range spans {
+			if terms[strings.ToLower(string(runes[span[0]:span[1]]))] {
+				firstMatch = span[0]
+				break
+			}
+		}
+
+		// Keep a bit of context before the match
+		from = max(0, firstMatch-maxLength/4)
+		to = min(len(runes), from+maxLength)
+		from = max(0, to-maxLength)
+
+		// Do not cut words in half, unless a single word is longer than the snippet
+		wordFrom, wordTo := from, to
+		for _, span := range spans {
+			if span[0] < wordFrom && wordFrom < span[1] {
+				wordFrom = span[1]
+			}
+			if span[0] < wordTo && wordTo < span[1] {
+				wordTo = span[0]
+			}
+		}
+		if wordFrom < wordTo {
+			from, to = wordFrom, wordTo
+		}
+	}
+
+	var sb strings.Builder
+	if from > 0 {
+		sb.WriteString("…")
+	}
+	pos := from
+	for _, span := range spans {
+		if span[0] < from || span[1] > to {
+			continue
+		}
+		word := string(runes[span[0]:span[1]])
+		if !terms[strings.ToLower(word)] {
+			continue
+		}
+		sb.WriteString(html.EscapeString(string(runes[pos:span[0]])))
+		sb.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		pos = span[1]
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		sb.WriteString("…")
+	}
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// MemoryIndex is an inverted index kept in the memory of the current process.
+// It is meant for tests and small deployments running on a single instance.
+type MemoryIndex struct {
+	mu sync.RWMutex
+	// Serializes the updates, so a document loaded earlier cannot
+	// replace one loaded later
+	updateMu  sync.Mutex
+	documents map[int32]Document
+	// Weighted number of occurrences of each term in each question
+	postings map[string]map[int32]float64
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		documents: make(map[int32]Document),
+		postings:  make(map[string]map[int32]float64),
+	}
+}
+
+// Index adds the document, or replaces it if the question is already indexed.
+func (i *MemoryIndex) Index(doc Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(doc.QuestionID)
+	i.documents[doc.QuestionID] = doc
+
+	add := func(text string, weight float64) {
+		for _, token := range Tokenize(text) {
+			if i.postings[token] == nil {
+				i.postings[token] = make(map[int32]float64)
+			}
+			i.postings[token][doc.QuestionID] += weight
+		}
+	}
+	add(doc.Title, titleWeight)
+	add(doc.Body, 1)
+	for _, answer := range doc.Answers {
+		add(answer, 1)
+	}
+}
+
+func (i *MemoryIndex) Remove(questionId int32) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(questionId)
+}
+
+func (i *MemoryIndex) remove(questionId int32) {
+	doc, ok := i.documents[questionId]
+	if !ok {
+		return
+	}
+
+	texts := append([]string{doc.Title, doc.Body}, doc.Answers...)
+	for _, text := range texts {
+		for _, token := range Tokenize(text) {
+			delete(i.postings[token], questionId)
+			if len(i.postings[token]) == 0 {
+				delete(i.postings, token)
+			}
+		}
+	}
+	delete(i.documents, questionId)
+}
+
+// Search scores the questions with TF-IDF, so rare words count more than common ones.
+func (i *MemoryIndex) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	terms := Terms(query)
+	scores := make(map[int32]float64)
+	for term := range terms {
+		postings := i.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		idf := math.Log(1 + float64(len(i.documents))/float64(len(postings)))
+		for questionId, frequency := range postings {
+			scores[questionId] += frequency * idf
+		}
+	}
+
+	questionIds := make([]int32, 0, len(scores))
+	for questionId := range scores {
+		questionIds = append(questionIds, questionId)
+	}
+	sort.Slice(questionIds, func(a, b int) bool {
+		if scores[questionIds[a]] != scores[questionIds[b]] {
+			return scores[questionIds[a]] > scores[questionIds[b]]
+		}
+		return questionIds[a] > questionIds[b]
+	})
+	if len(questionIds) > limit {
+		questionIds = questionIds[:limit]
+	}
+
+	results := make([]Result, 0, len(questionIds))
+	for _, questionId := range questionIds {
+		doc := i.documents[questionId]
+		results = append(results, newResult(doc.QuestionID, doc.Title, doc.Body, doc.Answers, terms, scores[questionId]))
+	}
+	return results, nil
+}
+
+// Update loads the document and indexes it while holding the update lock.
+// Searches are not blocked while the document is loaded.
+func (i *MemoryIndex) Update(ctx context.Context, questionId int32, load func() (*Document, error)) error {
+	i.updateMu.Lock()
+	defer i.updateMu.Unlock()
+
+	doc, err := load()
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		i.Remove(questionId)
+		return nil
+	}
+	i.Index(*doc)
+	return nil
+}
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"context"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// MySQLIndex uses the FULLTEXT indexes of the questions and answers tables,
+// which MySQL keeps up to date by itself.
+type MySQLIndex struct{}
+
+func NewMySQLIndex() *MySQLIndex {
+	return &MySQLIndex{}
+}
+
+func (i *MySQLIndex) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	db := database.GetDB()
+
+	rows, err := db.FullTextSearchQuestions(ctx, database.FullTextSearchQuestionsParams{
+		Query: query,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	terms := Terms(query)
+	results := make([]Result, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, newResult(row.ID, row.Title, row.Body, []string{row.AnswerBody}, terms, row.Score))
+	}
+	return results, nil
+}
+
+func (i *MySQLIndex) Update(ctx context.Context, questionId int32, load func() (*Document, error)) error {
+	return nil
+}
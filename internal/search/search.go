@@ -0,0 +1,109 @@
+package search
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// Weight of a title match compared to a body or answer match
+const titleWeight = 3
+
+// Document is the searchable text of a question and its answers.
+type Document struct {
+	QuestionID int32
+	Title      string
+	Body       string
+	Answers    []string
+}
+
+// Result is a matching question. Title and Snippet are HTML with the matched words in <mark> tags.
+type Result struct {
+	QuestionID int32
+	Title      string
+	Snippet    string
+	Score      float64
+}
+
+type SearchIndex interface {
+	// Search returns the questions matching the query, the most relevant first.
+	Search(ctx context.Context, query string, limit int) ([]Result, error)
+	// Update is called after a question or one of its answers has been created,
+	// changed or deleted. load returns the current document, or nil if the question
+	// has been deleted. Indexes that do not keep their own copy need not call it.
+	Update(ctx context.Context, questionId int32, load func() (*Document, error)) error
+}
+
+var searchIndex SearchIndex
+
+// UseSearchIndex selects the search index with SEARCH_INDEX:
+// mysql (default) uses the FULLTEXT indexes of the database,
+// memory builds an inverted index in the current process from the database.
+func UseSearchIndex() {
+	switch os.Getenv("SEARCH_INDEX") {
+	case "", "mysql":
+		searchIndex = NewMySQLIndex()
+	case "memory":
+		index := NewMemoryIndex()
+		if err := loadDocuments(context.Background(), index); err != nil {
+			log.Fatalln("Error building the search index.", err)
+		}
+		searchIndex = index
+	default:
+		log.Fatalln("Unknown SEARCH_INDEX " + os.Getenv("SEARCH_INDEX") + ".")
+	}
+}
+
+func GetSearchIndex() SearchIndex {
+	return searchIndex
+}
+
+func loadDocuments(ctx context.Context, index *MemoryIndex) error {
+	db := database.GetDB()
+
+	questions, err := db.GetAllQuestionTexts(ctx)
+	if err != nil {
+		return err
+	}
+	answers, err := db.GetAllAnswerTexts(ctx)
+	if err != nil {
+		return err
+	}
+
+	answersByQuestion := make(map[int32][]string)
+	for _, answer := range answers {
+		answersByQuestion[answer.QuestionID] = append(answersByQuestion[answer.QuestionID], answer.Body)
+	}
+	for _, question := range questions {
+		index.Index(Document{
+			QuestionID: question.ID,
+			Title:      question.Title,
+			Body:       question.Body,
+			Answers:    answersByQuestion[question.ID],
+		})
+	}
+	return nil
+}
+
+// newResult highlights the title and picks the snippet from the body,
+// or from the first answer containing a term if the body does not.
+func newResult(questionId int32, title string, body string, answers []string, terms map[string]bool, score float64) Result {
+	snippetSource := body
+	if !containsTerm(body, terms) {
+		for _, answer := range answers {
+			if containsTerm(answer, terms) {
+				snippetSource = answer
+				break
+			}
+		}
+	}
+
+	return Result{
+		QuestionID: questionId,
+		Title:      Highlight(title, terms, len([]rune(title))),
+		Snippet:    Highlight(snippetSource, terms, snippetLength),
+		Score:      score,
+	}
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", []string{}},
+		{"punctuation only", " -- !? ", []string{}},
+		{"lowercase", "Hello, World!", []string{"hello", "world"}},
+		{"digits", "Go 1.21 rocks", []string{"go", "1", "21", "rocks"}},
+		{"unicode", "Crème brûlée", []string{"crème", "brûlée"}},
+		{"symbols split words", "c++/go_lang", []string{"c", "go", "lang"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Tokenize(test.text)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		terms     map[string]bool
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "no match",
+			text:      "Hello world",
+			terms:     map[string]bool{"go": true},
+			maxLength: 100,
+			want:      "Hello world",
+		},
+		{
+			name:      "case insensitive",
+			text:      "Hello WORLD",
+			terms:     map[string]bool{"world": true},
+			maxLength: 100,
+			want:      "Hello <mark>WORLD</mark>",
+		},
+		{
+			name:      "whole words only",
+			text:      "going to go",
+			terms:     map[string]bool{"go": true},
+			maxLength: 100,
+			want:      "going to <mark>go</mark>",
+		},
+		{
+			name:      "escapes html",
+			text:      "<b>go</b> & go",
+			terms:     map[string]bool{"go": true},
+			maxLength: 100,
+			want:      "&lt;b&gt;<mark>go</mark>&lt;/b&gt; &amp; <mark>go</mark>",
+		},
+		{
+			name:      "cuts around the first match",
+			text:      "one two three four five six seven eight nine ten",
+			terms:     map[string]bool{"seven": true},
+			maxLength: 20,
+			want:      "… six <mark>seven</mark> eight …",
+		},
+		{
+			name:      "cuts the end when the match is at the start",
+			text:      "one two three four five six seven eight nine ten",
+			terms:     map[string]bool{"one": true},
+			maxLength: 20,
+			want:      "<mark>one</mark> two three four …",
+		},
+		{
+			name:      "cuts on characters, not bytes",
+			text:      "ééé ààà ùùù ööö",
+			terms:     map[string]bool{"ööö": true},
+			maxLength: 8,
+			want:      "… ùùù <mark>ööö</mark>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Highlight(test.text, test.terms, test.maxLength)
+			if got != test.want {
+				t.Errorf("Highlight(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndexSearch(t *testing.T) {
+	index := NewMemoryIndex()
+	index.Index(Document{QuestionID: 1, Title: "Closing channels", Body: "When should a channel be closed?"})
+	index.Index(Document{QuestionID: 2, Title: "Goroutine leaks", Body: "My goroutine blocks on channels forever."})
+	index.Index(Document{QuestionID: 3, Title: "Slices", Body: "How do I copy a slice?", Answers: []string{"Use copy, or append to a nil slice."}})
+	index.Index(Document{QuestionID: 4, Title: "Maps", Body: "Are maps safe for concurrent use?"})
+	index.Index(Document{QuestionID: 5, Title: "Maps", Body: "Are maps safe for concurrent use?"})
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []int32
+	}{
+		{"no match", "rust", 10, []int32{}},
+		{"title counts more than body", "channels", 10, []int32{1, 2}},
+		{"answers are searched", "append", 10, []int32{3}},
+		{"more matching words rank higher", "goroutine channels", 10, []int32{2, 1}},
+		{"rare words count more", "concurrent slice", 10, []int32{3, 5, 4}},
+		{"equal scores put the newest first", "maps", 10, []int32{5, 4}},
+		{"limit", "maps", 1, []int32{5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := index.Search(context.Background(), test.query, test.limit)
+			if err != nil {
+				t.Fatalf("Search(%q) returned an error: %v", test.query, err)
+			}
+			got := make([]int32, 0, len(results))
+			for _, result := range results {
+				got = append(got, result.QuestionID)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Search(%q) = %v, want %v", test.query, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMemoryIndexUpdate(t *testing.T) {
+	index := NewMemoryIndex()
+	index.Index(Document{QuestionID: 1, Title: "Closing channels"})
+
+	err := index.Update(context.Background(), 1, func() (*Document, error) {
+		return &Document{QuestionID: 1, Title: "Buffered channels"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if results, _ := index.Search(context.Background(), "closing", 10); len(results) != 0 {
+		t.Errorf("the old text is still indexed: %v", results)
+	}
+	if results, _ := index.Search(context.Background(), "buffered", 10); len(results) != 1 {
+		t.Errorf("the new text is not indexed: %v", results)
+	}
+
+	err = index.Update(context.Background(), 1, func() (*Document, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+	if results, _ := index.Search(context.Background(), "channels", 10); len(results) != 0 {
+		t.Errorf("the deleted question is still indexed: %v", results)
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// GenerateAPIKey returns a new API key, the prefix that is shown to the user
+// to tell their keys apart, and the hash that is stored in the database.
+func GenerateAPIKey() (string, string, string, error) {
+	token, _, err := GenerateToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	key := API_KEY_PREFIX + token
+	return key, key[:len(API_KEY_PREFIX)+8], HashToken(key), nil
+}
+
+func IsAPIKey(tokenStr string) bool {
+	return strings.HasPrefix(tokenStr, API_KEY_PREFIX)
+}
+
+func APIKeyFromContext(ctx context.Context) (database.ApiKey, bool) {
+	apiKey, ok := ctx.Value(API_KEY_CTX).(database.ApiKey)
+	return apiKey, ok
+}
+
+func SplitScopes(scopes string) []string {
+	return strings.Split(scopes, ",")
+}
+
+func HasScope(apiKey database.ApiKey, scope string) bool {
+	return slices.Contains(SplitScopes(apiKey.Scopes), scope)
+}
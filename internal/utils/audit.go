@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vuezy/go-ask-and-answer/internal/database"
+)
+
+// RecordAuditEvent appends an event to the audit log. The actor is the user
+// who has done the action, or 0 if unknown (e.g. a failed login with an unknown email).
+// Events that belong to a change should be recorded in the same transaction.
+// r is nil for events of background jobs, which have no IP address and user agent.
+func RecordAuditEvent(
+	ctx context.Context,
+	q *database.Queries,
+	r *http.Request,
+	actorId int32,
+	action string,
+	payload map[string]any,
+) error {
+	if payload == nil {
+		payload = map[string]any{}
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		log.Println("Error encoding the audit event payload.", err)
+		return err
+	}
+
+	ipAddress, userAgent := "", ""
+	if r != nil {
+		ipAddress, userAgent = GetClientIP(r), GetUserAgent(r)
+	}
+
+	err = q.CreateAuditEvent(ctx, database.CreateAuditEventParams{
+		ActorID:   sql.NullInt32{Int32: actorId, Valid: actorId != 0},
+		Action:    action,
+		IpAddress: ipAddress,
+		UserAgent: userAgent,
+		Payload:   payloadJSON,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Println("Error from q.CreateAuditEvent method.", err)
+	}
+	return err
+}
+
+// RecordCreditsChange records a change of the credits of a user in the audit log.
+// Nothing is recorded if the amount is 0. questionId is 0 if no question is involved.
+func RecordCreditsChange(
+	ctx context.Context,
+	q *database.Queries,
+	r *http.Request,
+	actorId int32,
+	userId int32,
+	amount int32,
+	reason string,
+	questionId int32,
+) error {
+	if amount == 0 {
+		return nil
+	}
+
+	payload := map[string]any{
+		"user_id": userId,
+		"amount":  amount,
+		"reason":  reason,
+	}
+	if questionId != 0 {
+		payload["question_id"] = questionId
+	}
+	return RecordAuditEvent(ctx, q, r, actorId, AUDIT_CREDITS_CHANGE, payload)
+}
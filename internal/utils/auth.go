@@ -5,29 +5,16 @@ import (
 	"database/sql"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-chi/jwtauth"
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/jwt"
 	"github.com/vuezy/go-ask-and-answer/internal/database"
 )
 
-var jwtAuth *jwtauth.JWTAuth
-
-func UseJWTAuthentication() {
-	secret := os.Getenv("JWT_SECRET")
-	jwtAuth = jwtauth.New("HS256", []byte(secret), nil)
-}
-
-func GetJWTAuth() *jwtauth.JWTAuth {
-	return jwtAuth
-}
-
-func IssueJWT(userId int32) (string, string, string, error) {
+func IssueJWT(userId int32, role string) (string, string, string, error) {
 	jti := uuid.New().String()
 	sub := strconv.Itoa(int(userId))
 	iat := time.Now().UTC().Unix()
@@ -39,9 +26,10 @@ func IssueJWT(userId int32) (string, string, string, error) {
 		"iat":     iat,
 		"exp":     exp + int64((time.Hour * 1).Seconds()),
 		"refresh": false,
+		"role":    role,
 	}
 
-	_, accessToken, err := jwtAuth.Encode(accessTokenClaims)
+	accessToken, err := signToken(accessTokenClaims)
 	if err != nil {
 		log.Println("Error issuing access token.", err)
 		return "", "", "", err
@@ -53,9 +41,10 @@ func IssueJWT(userId int32) (string, string, string, error) {
 		"iat":     iat,
 		"exp":     exp + int64((time.Hour * 24 * 3).Seconds()),
 		"refresh": true,
+		"role":    role,
 	}
 
-	_, refreshToken, err := jwtAuth.Encode(refreshTokenClaims)
+	refreshToken, err := signToken(refreshTokenClaims)
 	if err != nil {
 		log.Println("Error issuing refresh token.", err)
 		return "", "", "", err
@@ -63,15 +52,62 @@ func IssueJWT(userId int32) (string, string, string, error) {
 	return jti, accessToken, refreshToken, nil
 }
 
+// IssueChallengeToken issues a short-lived token proving that the user has passed
+// the password step of the login. It cannot be used as an access or refresh token.
+func IssueChallengeToken(userId int32) (string, error) {
+	iat := time.Now().UTC().Unix()
+	challengeTokenClaims := map[string]interface{}{
+		"jti":       uuid.New().String(),
+		"sub":       strconv.Itoa(int(userId)),
+		"iat":       iat,
+		"exp":       iat + int64((time.Minute * 5).Seconds()),
+		"challenge": true,
+	}
+
+	challengeToken, err := signToken(challengeTokenClaims)
+	if err != nil {
+		log.Println("Error issuing challenge token.", err)
+		return "", err
+	}
+	return challengeToken, nil
+}
+
+// VerifyChallengeToken returns the user and the claims needed to use the challenge token only once.
+func VerifyChallengeToken(tokenStr string) (userId int32, jti string, expiresAt time.Time, ok bool) {
+	token, err := decodeToken(tokenStr)
+	if err != nil || token == nil {
+		if err != nil {
+			log.Println("Error validating challenge token.", err)
+		}
+		return 0, "", time.Time{}, false
+	}
+
+	if err = jwt.Validate(token); err != nil {
+		log.Println("Error validating challenge token.", err)
+		return 0, "", time.Time{}, false
+	}
+	if token.PrivateClaims()["challenge"] != true || token.JwtID() == "" {
+		return 0, "", time.Time{}, false
+	}
+
+	sub, err := strconv.ParseInt(token.Subject(), 10, 32)
+	if err != nil {
+		log.Println("Error converting token.Subject() to integer.", err)
+		return 0, "", time.Time{}, false
+	}
+	return int32(sub), token.JwtID(), token.Expiration(), true
+}
+
 func VerifyToken(
-	ctx context.Context,
+	r *http.Request,
 	tokenStr string,
 	isRefreshToken bool,
 	clock jwt.Clock,
 ) (token jwt.Token, claims map[string]any, code int) {
 	db := database.GetDB()
+	ctx := r.Context()
 
-	token, err := jwtAuth.Decode(tokenStr)
+	token, err := decodeToken(tokenStr)
 	if err != nil || token == nil {
 		if err != nil {
 			log.Println("Error validating token.", err)
@@ -106,19 +142,28 @@ func VerifyToken(
 		return nil, nil, 401
 	}
 
-	count, err := db.CheckIfTokenIsActive(ctx, database.CheckIfTokenIsActiveParams{
-		Sub: int32(sub),
-		Jti: token.JwtID(),
-	})
-	if err != nil {
-		if err == sql.ErrNoRows {
+	active, generation := activeTokenCache.lookup(int32(sub), token.JwtID())
+	if !active {
+		count, err := db.CheckIfTokenIsActive(ctx, database.CheckIfTokenIsActiveParams{
+			Sub: int32(sub),
+			Jti: token.JwtID(),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil, 401
+			}
+			log.Println("Error from db.CheckIfTokenIsActive method.", err)
+			return nil, nil, 500
+		}
+		if count == 0 {
+			if isRefreshToken && token.PrivateClaims()["refresh"] == true {
+				if err = RevokeReusedRefreshToken(r, int32(sub), token.JwtID()); err != nil {
+					log.Println("Error revoking the refresh token family.", err)
+				}
+			}
 			return nil, nil, 401
 		}
-		log.Println("Error from db.CheckIfTokenIsActive method.", err)
-		return nil, nil, 500
-	}
-	if count == 0 {
-		return nil, nil, 401
+		activeTokenCache.store(int32(sub), token.JwtID(), generation)
 	}
 
 	claims, err = token.AsMap(ctx)
@@ -136,6 +181,42 @@ func VerifyToken(
 	return nil, nil, 401
 }
 
+// RevokeReusedRefreshToken revokes the whole token family (the session the
+// token was issued for) if the given refresh token has already been rotated.
+// A rotated refresh token showing up again means it has most likely been stolen.
+func RevokeReusedRefreshToken(r *http.Request, sub int32, jti string) error {
+	db := database.GetDB()
+	ctx := r.Context()
+
+	refreshToken, err := db.GetRefreshToken(ctx, database.GetRefreshTokenParams{
+		Sub: sub,
+		Jti: jti,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+	if !refreshToken.UsedAt.Valid {
+		return nil
+	}
+
+	_, err = db.DeleteActiveToken(ctx, database.DeleteActiveTokenParams{
+		ID:  refreshToken.FamilyID,
+		Sub: sub,
+	})
+	if err != nil {
+		return err
+	}
+	InvalidateActiveTokens(sub)
+
+	return RecordAuditEvent(ctx, db, r, sub, AUDIT_REFRESH_TOKEN_REUSE, map[string]any{
+		"jti":       jti,
+		"family_id": refreshToken.FamilyID,
+	})
+}
+
 func ExtractTokenFromHeader(r *http.Request) string {
 	authHeader := r.Header.Get("Authorization")
 	if len(authHeader) > 7 && strings.ToUpper(authHeader[0:6]) == "BEARER" {
@@ -145,8 +226,17 @@ func ExtractTokenFromHeader(r *http.Request) string {
 	}
 }
 
+// GetTokenSubject returns the ID of the authenticated user,
+// whether the request has been authenticated with an access token or an API key.
 func GetTokenSubject(ctx context.Context) int32 {
+	if apiKey, ok := APIKeyFromContext(ctx); ok {
+		return apiKey.UserID
+	}
+
 	_, token, _ := JWTAuthFromContext(ctx)
+	if token == nil {
+		return 0
+	}
 	sub, err := strconv.ParseInt(token.Subject(), 10, 32)
 	if err != nil {
 		log.Println("Error converting token.Subject() to integer.", err)
@@ -155,6 +245,22 @@ func GetTokenSubject(ctx context.Context) int32 {
 	return int32(sub)
 }
 
+// GetTokenRole returns the role the token has been issued with.
+// Tokens issued before roles existed belong to regular users.
+func GetTokenRole(ctx context.Context) string {
+	_, _, claims := JWTAuthFromContext(ctx)
+	role, ok := claims["role"].(string)
+	if !ok {
+		return ROLE_USER
+	}
+	return role
+}
+
+// IsModerator reports whether the role may moderate content of other users.
+func IsModerator(role string) bool {
+	return role == ROLE_MODERATOR || role == ROLE_ADMIN
+}
+
 func JWTAuthFromContext(ctx context.Context) (string, jwt.Token, map[string]any) {
 	tokenStr, ok := ctx.Value(TOKEN_STR_CTX).(string)
 	if !ok {
@@ -7,3 +7,32 @@ const ACCESS_TOKEN_STR_CTX = "AccessTokenStr"
 const ACCESS_TOKEN_CTX = "AccessToken"
 const VALIDATED_CTX = "Validated"
 const PARSED_ID_CTX = "ParsedID"
+const API_KEY_CTX = "APIKey"
+
+const ROLE_USER = "user"
+const ROLE_MODERATOR = "moderator"
+const ROLE_ADMIN = "admin"
+
+const API_KEY_PREFIX = "aak_"
+
+const SCOPE_QUESTIONS_READ = "questions:read"
+const SCOPE_QUESTIONS_WRITE = "questions:write"
+const SCOPE_ANSWERS_READ = "answers:read"
+const SCOPE_ANSWERS_WRITE = "answers:write"
+const SCOPE_VOTES_WRITE = "votes:write"
+
+// Email of the placeholder user that takes over the content of deleted accounts
+const DELETED_USER_EMAIL = "deleted-user@invalid"
+
+const AUDIT_LOGIN = "login"
+const AUDIT_LOGIN_FAILED = "login_failed"
+const AUDIT_TOKEN_REFRESH = "token_refresh"
+const AUDIT_REFRESH_TOKEN_REUSE = "refresh_token_reuse"
+const AUDIT_QUESTION_CLOSE = "question_close"
+const AUDIT_QUESTION_DELETE = "question_delete"
+const AUDIT_CREDITS_CHANGE = "credits_change"
+const AUDIT_ROLE_CHANGE = "role_change"
+
+const BOUNTY_OPEN = "open"
+const BOUNTY_AWARDED = "awarded"
+const BOUNTY_REFUNDED = "refunded"
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor encodes the position of the last returned row into an opaque
+// cursor, so clients cannot depend on what it contains.
+func EncodeCursor(position any) (string, error) {
+	positionJSON, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(positionJSON), nil
+}
+
+func DecodeCursor(cursor string, position any) error {
+	positionJSON, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(positionJSON, position)
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCursor struct {
+	ID        int32     `json:"id"`
+	Closed    bool      `json:"closed"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	position := testCursor{
+		ID:        42,
+		Closed:    true,
+		UpdatedAt: time.Date(2024, 1, 2, 3, 4, 5, 600, time.UTC),
+	}
+
+	cursor, err := EncodeCursor(position)
+	if err != nil {
+		t.Fatalf("EncodeCursor returned an error: %v", err)
+	}
+	if strings.ContainsAny(cursor, "+/=") {
+		t.Errorf("the cursor %q is not safe to use in a URL", cursor)
+	}
+
+	var decoded testCursor
+	if err = DecodeCursor(cursor, &decoded); err != nil {
+		t.Fatalf("DecodeCursor(%q) returned an error: %v", cursor, err)
+	}
+	if decoded != position {
+		t.Errorf("DecodeCursor(%q) = %+v, want %+v", cursor, decoded, position)
+	}
+}
+
+func TestDecodeCursorErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"not json", "bm90IGpzb24"},
+		{"wrong type", "eyJpZCI6ImZvcnR5LXR3byJ9"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded testCursor
+			if err := DecodeCursor(test.cursor, &decoded); err == nil {
+				t.Errorf("DecodeCursor(%q) = %+v, want an error", test.cursor, decoded)
+			}
+		})
+	}
+}
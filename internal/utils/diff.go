@@ -0,0 +1,59 @@
+package utils
+
+import "strings"
+
+const DIFF_EQUAL = "equal"
+const DIFF_INSERT = "insert"
+const DIFF_DELETE = "delete"
+
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+// DiffLines returns the line-level difference between two texts, computed from
+// their longest common subsequence of lines. Deleted lines come before the
+// inserted lines that replace them.
+func DiffLines(oldText string, newText string) []DiffLine {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence
+	// of oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]DiffLine, 0, max(len(oldLines), len(newLines)))
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		if oldLines[i] == newLines[j] {
+			diff = append(diff, DiffLine{Op: DIFF_EQUAL, Text: oldLines[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			diff = append(diff, DiffLine{Op: DIFF_DELETE, Text: oldLines[i]})
+			i++
+		} else {
+			diff = append(diff, DiffLine{Op: DIFF_INSERT, Text: newLines[j]})
+			j++
+		}
+	}
+	for ; i < len(oldLines); i++ {
+		diff = append(diff, DiffLine{Op: DIFF_DELETE, Text: oldLines[i]})
+	}
+	for ; j < len(newLines); j++ {
+		diff = append(diff, DiffLine{Op: DIFF_INSERT, Text: newLines[j]})
+	}
+	return diff
+}
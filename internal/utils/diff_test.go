@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []DiffLine
+	}{
+		{
+			name:    "equal",
+			oldText: "a\nb",
+			newText: "a\nb",
+			want:    []DiffLine{{DIFF_EQUAL, "a"}, {DIFF_EQUAL, "b"}},
+		},
+		{
+			name:    "from empty",
+			oldText: "",
+			newText: "a",
+			want:    []DiffLine{{DIFF_DELETE, ""}, {DIFF_INSERT, "a"}},
+		},
+		{
+			name:    "line appended",
+			oldText: "a\nb",
+			newText: "a\nb\nc",
+			want:    []DiffLine{{DIFF_EQUAL, "a"}, {DIFF_EQUAL, "b"}, {DIFF_INSERT, "c"}},
+		},
+		{
+			name:    "line removed",
+			oldText: "a\nb\nc",
+			newText: "a\nc",
+			want:    []DiffLine{{DIFF_EQUAL, "a"}, {DIFF_DELETE, "b"}, {DIFF_EQUAL, "c"}},
+		},
+		{
+			name:    "deleted before inserted",
+			oldText: "a\nb\nc",
+			newText: "a\nx\nc",
+			want:    []DiffLine{{DIFF_EQUAL, "a"}, {DIFF_DELETE, "b"}, {DIFF_INSERT, "x"}, {DIFF_EQUAL, "c"}},
+		},
+		{
+			name:    "longest common subsequence",
+			oldText: "a\nb\nc\nd",
+			newText: "b\nc\na\nd",
+			want: []DiffLine{
+				{DIFF_DELETE, "a"}, {DIFF_EQUAL, "b"}, {DIFF_EQUAL, "c"}, {DIFF_INSERT, "a"}, {DIFF_EQUAL, "d"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DiffLines(test.oldText, test.newText)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("DiffLines(%q, %q) = %v, want %v", test.oldText, test.newText, got, test.want)
+			}
+		})
+	}
+}
+
+// Both texts can be rebuilt from the diff, so a revision can be shown
+// or rolled back from it.
+func TestDiffLinesRoundTrip(t *testing.T) {
+	texts := []string{
+		"",
+		"one line",
+		"first\nsecond\nthird",
+		"first\nthird\nfourth\n",
+		"\n\nfirst\n\nsecond",
+		"third\nsecond\nfirst",
+	}
+
+	for _, oldText := range texts {
+		for _, newText := range texts {
+			var oldLines, newLines []string
+			for _, line := range DiffLines(oldText, newText) {
+				if line.Op != DIFF_INSERT {
+					oldLines = append(oldLines, line.Text)
+				}
+				if line.Op != DIFF_DELETE {
+					newLines = append(newLines, line.Text)
+				}
+			}
+
+			if got := strings.Join(oldLines, "\n"); got != oldText {
+				t.Errorf("the diff of %q and %q rebuilds the old text as %q", oldText, newText, got)
+			}
+			if got := strings.Join(newLines, "\n"); got != newText {
+				t.Errorf("the diff of %q and %q rebuilds the new text as %q", oldText, newText, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// GetIntEnv returns the value of an integer environment variable,
+// or defaultValue if it is not set.
+func GetIntEnv(name string, defaultValue int) int {
+	valueStr := os.Getenv(name)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		log.Fatalln("Error parsing "+name+".", err)
+	}
+	return value
+}
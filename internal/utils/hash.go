@@ -1,13 +1,147 @@
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const argon2SaltLength = 16
+const argon2KeyLength = 32
+
+var ErrPasswordMismatch = errors.New("the password does not match the hash")
+var errInvalidHash = errors.New("the hash is not in a supported format")
+
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+var passwordHashAlgorithm = "bcrypt"
+var bcryptCost = 14
+var argon2Config = argon2Params{memory: 64 * 1024, time: 3, threads: 2}
+
+// UsePasswordHashing configures the algorithm used for new password hashes.
+// Hashes are self-describing, so passwords hashed with other algorithms
+// or parameters can still be checked, and are rehashed on the next login.
+func UsePasswordHashing() {
+	switch os.Getenv("PASSWORD_HASH_ALGORITHM") {
+	case "", "bcrypt":
+		passwordHashAlgorithm = "bcrypt"
+		bcryptCost = GetIntEnv("BCRYPT_COST", 14)
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			log.Fatalln("BCRYPT_COST must be between", bcrypt.MinCost, "and", bcrypt.MaxCost)
+		}
+	case "argon2id":
+		passwordHashAlgorithm = "argon2id"
+		argon2Config = argon2Params{
+			memory:  uint32(GetIntEnv("ARGON2_MEMORY", 64*1024)),
+			time:    uint32(GetIntEnv("ARGON2_TIME", 3)),
+			threads: uint8(GetIntEnv("ARGON2_THREADS", 2)),
+		}
+		if argon2Config.memory < 8*uint32(argon2Config.threads) || argon2Config.time < 1 || argon2Config.threads < 1 {
+			log.Fatalln("Invalid argon2id parameters.")
+		}
+	default:
+		log.Fatalln("Unknown PASSWORD_HASH_ALGORITHM " + os.Getenv("PASSWORD_HASH_ALGORITHM") + ".")
+	}
+}
 
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	if passwordHashAlgorithm == "argon2id" {
+		return hashArgon2id(password, argon2Config)
+	}
+
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
 	return string(bytes), err
 }
 
 func CheckPasswordHash(password, hash string) error {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		return checkArgon2idHash(password, hash)
+	}
+
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err
 }
+
+// PasswordNeedsRehash reports whether the hash has been created with
+// another algorithm or other parameters than the configured ones.
+func PasswordNeedsRehash(hash string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		params, _, _, err := decodeArgon2idHash(hash)
+		return err != nil || passwordHashAlgorithm != "argon2id" || params != argon2Config
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || passwordHashAlgorithm != "bcrypt" || cost != bcryptCost
+}
+
+// hashArgon2id returns the hash in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+func hashArgon2id(password string, params argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, argon2KeyLength)
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func checkArgon2idHash(password string, hash string) error {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func decodeArgon2idHash(hash string) (argon2Params, []byte, []byte, error) {
+	params := argon2Params{}
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidHash
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads)
+	if err != nil || params.memory == 0 || params.time == 0 || params.threads == 0 {
+		// argon2.IDKey panics when the time or the threads are 0
+		return params, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+	return params, salt, key, nil
+}
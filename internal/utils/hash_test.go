@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Small parameters, so the tests run fast
+var testArgon2Params = argon2Params{memory: 64, time: 1, threads: 1}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	hash, err := hashArgon2id("correct horse battery staple", testArgon2Params)
+	if err != nil {
+		t.Fatalf("hashArgon2id returned an error: %v", err)
+	}
+
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		t.Fatalf("decodeArgon2idHash(%q) returned an error: %v", hash, err)
+	}
+	if params != testArgon2Params {
+		t.Errorf("decodeArgon2idHash returned %+v, want %+v", params, testArgon2Params)
+	}
+	if len(salt) != argon2SaltLength {
+		t.Errorf("the salt has %d bytes, want %d", len(salt), argon2SaltLength)
+	}
+	if len(key) != argon2KeyLength {
+		t.Errorf("the key has %d bytes, want %d", len(key), argon2KeyLength)
+	}
+
+	if err = CheckPasswordHash("correct horse battery staple", hash); err != nil {
+		t.Errorf("CheckPasswordHash with the right password returned %v", err)
+	}
+	if err = CheckPasswordHash("wrong horse battery staple", hash); err != ErrPasswordMismatch {
+		t.Errorf("CheckPasswordHash with a wrong password returned %v, want %v", err, ErrPasswordMismatch)
+	}
+}
+
+func TestDecodeArgon2idHashErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"empty", ""},
+		{"other algorithm", "$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"other version", "$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"missing part", "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0"},
+		{"invalid parameters", "$argon2id$v=19$m=x,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"zero memory", "$argon2id$v=19$m=0,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"zero time", "$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"zero threads", "$argon2id$v=19$m=64,t=1,p=0$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5"},
+		{"invalid salt", "$argon2id$v=19$m=64,t=1,p=1$not*base64$a2V5a2V5a2V5"},
+		{"empty salt", "$argon2id$v=19$m=64,t=1,p=1$$a2V5a2V5a2V5"},
+		{"invalid key", "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$not*base64"},
+		{"empty key", "$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, _, err := decodeArgon2idHash(test.hash); err != errInvalidHash {
+				t.Errorf("decodeArgon2idHash(%q) returned %v, want %v", test.hash, err, errInvalidHash)
+			}
+		})
+	}
+}
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	oldAlgorithm, oldCost, oldConfig := passwordHashAlgorithm, bcryptCost, argon2Config
+	t.Cleanup(func() {
+		passwordHashAlgorithm, bcryptCost, argon2Config = oldAlgorithm, oldCost, oldConfig
+	})
+
+	argon2Hash, err := hashArgon2id("password", testArgon2Params)
+	if err != nil {
+		t.Fatalf("hashArgon2id returned an error: %v", err)
+	}
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt.GenerateFromPassword returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		algorithm string
+		cost      int
+		config    argon2Params
+		hash      string
+		want      bool
+	}{
+		{"same argon2id parameters", "argon2id", bcrypt.MinCost, testArgon2Params, argon2Hash, false},
+		{"other argon2id parameters", "argon2id", bcrypt.MinCost, argon2Params{memory: 128, time: 1, threads: 1}, argon2Hash, true},
+		{"argon2id to bcrypt", "bcrypt", bcrypt.MinCost, testArgon2Params, argon2Hash, true},
+		{"same bcrypt cost", "bcrypt", bcrypt.MinCost, testArgon2Params, string(bcryptHash), false},
+		{"other bcrypt cost", "bcrypt", bcrypt.MinCost + 1, testArgon2Params, string(bcryptHash), true},
+		{"bcrypt to argon2id", "argon2id", bcrypt.MinCost, testArgon2Params, string(bcryptHash), true},
+		{"invalid hash", "bcrypt", bcrypt.MinCost, testArgon2Params, "invalid", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			passwordHashAlgorithm, bcryptCost, argon2Config = test.algorithm, test.cost, test.config
+			if got := PasswordNeedsRehash(test.hash); got != test.want {
+				t.Errorf("PasswordNeedsRehash = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"io"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func RespondWithJSON(w http.ResponseWriter, code int, body map[string]any) {
@@ -50,6 +53,16 @@ func RespondWith401Error(w http.ResponseWriter) {
 	})
 }
 
+func RespondWith429Error(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	RespondWithJSON(w, 429, map[string]any{
+		"type":        "rate_limit_error",
+		"msg":         "Too many failed attempts. Please try again later",
+		"retry_after": seconds,
+	})
+}
+
 func AskToReauthenticate(w http.ResponseWriter) {
 	RespondWithJSON(w, 401, map[string]any{
 		"type": "authentication_error",
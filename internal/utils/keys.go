@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+type jwtKey struct {
+	alg jwa.SignatureAlgorithm
+	key any
+}
+
+var signingKeyId string
+var signingKey jwtKey
+var verificationKeys map[string]jwtKey
+var publicKeys []jwk.Key
+
+/*
+UseJWTAuthentication loads the keys used to sign and verify JWTs.
+
+With the default HS256 algorithm, tokens are signed with JWT_SECRET.
+With RS256, ES256 or EdDSA, tokens are signed with the private key in JWT_PRIVATE_KEY_FILE
+and carry JWT_KEY_ID as their kid header.
+Additional public keys listed in JWT_VERIFICATION_KEYS (kid=path, comma-separated)
+are still accepted, so the signing key can be rotated without invalidating issued tokens.
+If JWT_SECRET is set together with an asymmetric algorithm,
+tokens without a kid header (issued with HS256) remain valid until they expire.
+*/
+func UseJWTAuthentication() {
+	signingKeyId = ""
+	verificationKeys = make(map[string]jwtKey)
+	publicKeys = make([]jwk.Key, 0)
+
+	alg := jwa.SignatureAlgorithm(os.Getenv("JWT_ALGORITHM"))
+	if alg == "" {
+		alg = jwa.HS256
+	}
+
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		verificationKeys[""] = jwtKey{alg: jwa.HS256, key: []byte(secret)}
+	}
+
+	if alg == jwa.HS256 {
+		secretKey, ok := verificationKeys[""]
+		if !ok {
+			log.Fatalln("JWT_SECRET must be set to use the HS256 algorithm.")
+		}
+		signingKey = secretKey
+		return
+	}
+
+	signingKeyId = os.Getenv("JWT_KEY_ID")
+	if signingKeyId == "" {
+		log.Fatalln("JWT_KEY_ID must be set to use the " + alg + " algorithm.")
+	}
+
+	privateKey, err := readPrivateKeyFile(os.Getenv("JWT_PRIVATE_KEY_FILE"))
+	if err != nil {
+		log.Fatalln("Error loading the JWT signing key.", err)
+	}
+	keyAlg, err := algorithmOf(privateKey.Public())
+	if err != nil || keyAlg != alg {
+		log.Fatalln("The JWT signing key cannot be used with the " + alg + " algorithm.")
+	}
+
+	signingKey = jwtKey{alg: alg, key: privateKey}
+	if err = addVerificationKey(signingKeyId, privateKey.Public()); err != nil {
+		log.Fatalln("Error loading the JWT signing key.", err)
+	}
+
+	for _, entry := range strings.Split(os.Getenv("JWT_VERIFICATION_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, path, found := strings.Cut(entry, "=")
+		if !found || kid == "" {
+			log.Fatalln("JWT_VERIFICATION_KEYS entries must have the form kid=path.")
+		}
+		publicKey, err := readPublicKeyFile(path)
+		if err != nil {
+			log.Fatalln("Error loading the JWT verification key "+kid+".", err)
+		}
+		if err = addVerificationKey(kid, publicKey); err != nil {
+			log.Fatalln("Error loading the JWT verification key "+kid+".", err)
+		}
+	}
+}
+
+// GetPublicKeys returns the public verification keys in JWK format.
+func GetPublicKeys() []jwk.Key {
+	return publicKeys
+}
+
+func signToken(claims map[string]any) (string, error) {
+	token := jwt.New()
+	for name, value := range claims {
+		if err := token.Set(name, value); err != nil {
+			return "", err
+		}
+	}
+
+	headers := jws.NewHeaders()
+	if signingKeyId != "" {
+		if err := headers.Set(jws.KeyIDKey, signingKeyId); err != nil {
+			return "", err
+		}
+	}
+
+	signed, err := jwt.Sign(token, signingKey.alg, signingKey.key, jwt.WithHeaders(headers))
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
+
+func decodeToken(tokenStr string) (jwt.Token, error) {
+	message, err := jws.ParseString(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	signatures := message.Signatures()
+	if len(signatures) != 1 {
+		return nil, errors.New("the token must have exactly one signature")
+	}
+
+	kid := signatures[0].ProtectedHeaders().KeyID()
+	key, ok := verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return jwt.ParseString(tokenStr, jwt.WithVerify(key.alg, key.key))
+}
+
+func addVerificationKey(kid string, publicKey crypto.PublicKey) error {
+	alg, err := algorithmOf(publicKey)
+	if err != nil {
+		return err
+	}
+
+	key, err := jwk.New(publicKey)
+	if err != nil {
+		return err
+	}
+	if err = key.Set(jwk.KeyIDKey, kid); err != nil {
+		return err
+	}
+	if err = key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return err
+	}
+	if err = key.Set(jwk.KeyUsageKey, "sig"); err != nil {
+		return err
+	}
+
+	verificationKeys[kid] = jwtKey{alg: alg, key: publicKey}
+	publicKeys = append(publicKeys, key)
+	return nil
+}
+
+func algorithmOf(publicKey crypto.PublicKey) (jwa.SignatureAlgorithm, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwa.RS256, nil
+	case *ecdsa.PublicKey:
+		if key.Curve == elliptic.P256() {
+			return jwa.ES256, nil
+		}
+		return "", errors.New("only P-256 ECDSA keys are supported")
+	case ed25519.PublicKey:
+		return jwa.EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+func readPrivateKeyFile(path string) (crypto.Signer, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+func readPublicKeyFile(path string) (crypto.PublicKey, error) {
+	block, err := readPEMFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+func readPEMFile(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"unicode/utf8"
+)
+
+// Maximum number of bytes of a user agent kept in the audit log
+const maxUserAgentLength = 256
+
+func GetClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// GetUserAgent returns the user agent cut to maxUserAgentLength bytes.
+// It is cut before a character that would not fit, so it stays valid UTF-8.
+func GetUserAgent(r *http.Request) string {
+	userAgent := r.UserAgent()
+	if len(userAgent) <= maxUserAgentLength {
+		return userAgent
+	}
+
+	end := maxUserAgentLength
+	for end > 0 && !utf8.RuneStart(userAgent[end]) {
+		end--
+	}
+	return userAgent[:end]
+}
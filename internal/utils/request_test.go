@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGetUserAgent(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{"short", "curl/8.0", "curl/8.0"},
+		{"exactly the maximum", strings.Repeat("a", 256), strings.Repeat("a", 256)},
+		{"too long", strings.Repeat("a", 300), strings.Repeat("a", 256)},
+		{"character across the limit", strings.Repeat("a", 255) + "é", strings.Repeat("a", 255)},
+		{"character before the limit", strings.Repeat("a", 254) + "é" + "b", strings.Repeat("a", 254) + "é"},
+		{"4-byte characters", strings.Repeat("😀", 70), strings.Repeat("😀", 64)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("User-Agent", test.userAgent)
+
+			got := GetUserAgent(r)
+			if got != test.want {
+				t.Errorf("GetUserAgent returned %d bytes, want %d", len(got), len(test.want))
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("GetUserAgent returned invalid UTF-8")
+			}
+		})
+	}
+}
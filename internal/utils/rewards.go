@@ -0,0 +1,27 @@
+package utils
+
+import "log"
+
+// Reward is what a user earns for a contribution, in addition to the votes.
+type Reward struct {
+	Points  int32
+	Credits int32
+}
+
+var acceptedAnswerReward = Reward{Points: 15, Credits: 5}
+
+// UseRewards configures the rewards with ACCEPTED_ANSWER_POINTS and ACCEPTED_ANSWER_CREDITS.
+func UseRewards() {
+	acceptedAnswerReward = Reward{
+		Points:  int32(GetIntEnv("ACCEPTED_ANSWER_POINTS", 15)),
+		Credits: int32(GetIntEnv("ACCEPTED_ANSWER_CREDITS", 5)),
+	}
+	if acceptedAnswerReward.Points < 0 || acceptedAnswerReward.Credits < 0 {
+		log.Fatalln("ACCEPTED_ANSWER_POINTS and ACCEPTED_ANSWER_CREDITS must not be negative.")
+	}
+}
+
+// GetAcceptedAnswerReward returns what the author of an accepted answer earns.
+func GetAcceptedAnswerReward() Reward {
+	return acceptedAnswerReward
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const MAX_TAGS_PER_QUESTION = 5
+
+// Tags start with a letter or digit, followed by letters, digits and
+// the characters + # . - (so tags like c++, c# and node.js are possible).
+var tagRegex = regexp.MustCompile("^[a-z0-9][a-z0-9+#.-]{0,29}$")
+
+// NormalizeTag returns the tag in lowercase, the form it is stored in,
+// and reports whether it is a valid tag.
+func NormalizeTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return tag, tagRegex.MatchString(tag)
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+var tokenCacheHits = expvar.NewInt("token_cache_hits")
+var tokenCacheMisses = expvar.NewInt("token_cache_misses")
+
+// tokenCache remembers for a short time which (sub, jti) pairs are active,
+// so VerifyToken does not have to ask the database on every request.
+// Only active tokens are cached. Entries are grouped by user, because most
+// revocations (logout everywhere, password change, ...) affect all the tokens of a user.
+type tokenCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	size       int
+	entries    map[int32]map[string]time.Time
+	// generation changes on every invalidation. A lookup that started before
+	// an invalidation must not store its (possibly stale) result afterwards.
+	generation uint64
+}
+
+var activeTokenCache = newTokenCache(30*time.Second, 10000)
+
+func newTokenCache(ttl time.Duration, maxEntries int) *tokenCache {
+	return &tokenCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[int32]map[string]time.Time{},
+	}
+}
+
+// UseTokenCache configures the active token cache.
+// Setting TOKEN_CACHE_TTL to 0 disables it.
+func UseTokenCache() {
+	ttl := GetIntEnv("TOKEN_CACHE_TTL", 30)
+	maxEntries := GetIntEnv("TOKEN_CACHE_SIZE", 10000)
+	activeTokenCache = newTokenCache(time.Duration(ttl)*time.Second, maxEntries)
+}
+
+// lookup reports whether the token is known to be active. If it is not,
+// the returned generation has to be passed to store after checking the database.
+func (c *tokenCache) lookup(sub int32, jti string) (bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt, ok := c.entries[sub][jti]
+	if ok && time.Now().Before(expiresAt) {
+		tokenCacheHits.Add(1)
+		return true, c.generation
+	}
+	tokenCacheMisses.Add(1)
+	return false, c.generation
+}
+
+func (c *tokenCache) store(sub int32, jti string, generation uint64) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if c.size >= c.maxEntries {
+		c.evict()
+	}
+
+	tokens, ok := c.entries[sub]
+	if !ok {
+		tokens = map[string]time.Time{}
+		c.entries[sub] = tokens
+	}
+	if _, exists := tokens[jti]; !exists {
+		c.size++
+	}
+	tokens[jti] = time.Now().Add(c.ttl)
+}
+
+// evict removes the expired entries, or every entry if none has expired yet.
+func (c *tokenCache) evict() {
+	now := time.Now()
+	for sub, tokens := range c.entries {
+		for jti, expiresAt := range tokens {
+			if !now.Before(expiresAt) {
+				delete(tokens, jti)
+				c.size--
+			}
+		}
+		if len(tokens) == 0 {
+			delete(c.entries, sub)
+		}
+	}
+
+	if c.size >= c.maxEntries {
+		c.entries = map[int32]map[string]time.Time{}
+		c.size = 0
+	}
+}
+
+func (c *tokenCache) invalidate(sub int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.size -= len(c.entries[sub])
+	delete(c.entries, sub)
+	c.generation++
+}
+
+// InvalidateActiveTokens forgets the cached tokens of the user. It must be called
+// whenever active tokens of the user are changed or deleted, after the change
+// has been committed, otherwise revoked tokens stay usable until the cache entry expires.
+func InvalidateActiveTokens(sub int32) {
+	activeTokenCache.invalidate(sub)
+}
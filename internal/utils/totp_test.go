@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// The secret of the RFC 4226 and RFC 6238 test vectors ("12345678901234567890")
+var rfcKey = []byte("12345678901234567890")
+
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateHOTP(t *testing.T) {
+	// RFC 4226, Appendix D
+	want := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+
+	for counter, code := range want {
+		if got := generateHOTP(rfcKey, uint64(counter)); got != code {
+			t.Errorf("generateHOTP(%d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238, Appendix B (SHA1), cut to the last 6 digits
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, vector := range vectors {
+		now := time.Unix(vector.unix, 0)
+		counter, ok := ValidateTOTP(rfcSecret, vector.code, now)
+		if !ok {
+			t.Errorf("ValidateTOTP(%s) at %d = false, want true", vector.code, vector.unix)
+			continue
+		}
+		if want := vector.unix / totpPeriod; counter != want {
+			t.Errorf("ValidateTOTP(%s) at %d returned time step %d, want %d", vector.code, vector.unix, counter, want)
+		}
+	}
+}
+
+func TestValidateTOTPWindow(t *testing.T) {
+	// 050471 is the code of the time step 37037037
+	stepStart := time.Unix(37037037*totpPeriod, 0)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		now    time.Time
+		want   bool
+	}{
+		{"current step", rfcSecret, "050471", stepStart, true},
+		{"end of the step", rfcSecret, "050471", stepStart.Add(29 * time.Second), true},
+		{"one step later", rfcSecret, "050471", stepStart.Add(totpPeriod * time.Second), true},
+		{"one step earlier", rfcSecret, "050471", stepStart.Add(-time.Second), true},
+		{"two steps later", rfcSecret, "050471", stepStart.Add(2 * totpPeriod * time.Second), false},
+		{"two steps earlier", rfcSecret, "050471", stepStart.Add(-totpPeriod*time.Second - time.Second), false},
+		{"lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "050471", stepStart, true},
+		{"wrong code", rfcSecret, "050472", stepStart, false},
+		{"too short", rfcSecret, "05047", stepStart, false},
+		{"too long", rfcSecret, "0504710", stepStart, false},
+		{"invalid secret", "not base32!", "050471", stepStart, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, got := ValidateTOTP(test.secret, test.code, test.now); got != test.want {
+				t.Errorf("ValidateTOTP(%q, %q) = %v, want %v", test.secret, test.code, got, test.want)
+			}
+		})
+	}
+}
@@ -6,7 +6,12 @@ import (
 	"os"
 
 	"github.com/joho/godotenv"
+	"github.com/vuezy/go-ask-and-answer/internal/bounties"
 	"github.com/vuezy/go-ask-and-answer/internal/database"
+	"github.com/vuezy/go-ask-and-answer/internal/limiter"
+	"github.com/vuezy/go-ask-and-answer/internal/mailer"
+	"github.com/vuezy/go-ask-and-answer/internal/passwords"
+	"github.com/vuezy/go-ask-and-answer/internal/search"
 	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
 
@@ -20,6 +25,14 @@ func init() {
 func main() {
 	database.ConnectToDatabase()
 	utils.UseJWTAuthentication()
+	utils.UseTokenCache()
+	utils.UsePasswordHashing()
+	utils.UseRewards()
+	passwords.UsePasswordPolicy()
+	mailer.UseMailer()
+	limiter.UseLoginLimiter()
+	search.UseSearchIndex()
+	bounties.UseBounties()
 	router := setUpRouter()
 
 	server := &http.Server{
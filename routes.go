@@ -1,11 +1,13 @@
 package main
 
 import (
+	"expvar"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/vuezy/go-ask-and-answer/internal/handlers"
 	"github.com/vuezy/go-ask-and-answer/internal/middlewares"
+	"github.com/vuezy/go-ask-and-answer/internal/utils"
 )
 
 func setUpRouter() http.Handler {
@@ -16,47 +18,121 @@ func setUpRouter() http.Handler {
 	v1Router.Group(func(r chi.Router) {
 		r.With(middlewares.ValidateLoginPayload).
 			Post("/login", handlers.Login)
+		r.With(middlewares.ValidateTwoFactorLoginPayload).
+			Post("/login/2fa", handlers.LoginWithTwoFactor)
 		r.With(middlewares.ValidateRegisterPayload).
 			Post("/register", handlers.Register)
 		r.With(middlewares.VerifyRefreshToken).
 			Post("/refresh", handlers.RefreshTokens)
+		r.With(middlewares.ValidateForgotPasswordPayload).
+			Post("/password/forgot", handlers.ForgotPassword)
+		r.With(middlewares.ValidateResetPasswordPayload).
+			Post("/password/reset", handlers.ResetPassword)
+		r.With(middlewares.ValidateVerifyEmailPayload).
+			Post("/email/verify", handlers.VerifyEmail)
 	})
 
 	// Protected routes (require authentication)
 	v1Router.Group(func(r chi.Router) {
 		r.Use(middlewares.VerifyAccessToken)
 
+		r.Post("/logout", handlers.Logout)
+		r.Post("/logout-all", handlers.LogoutAll)
+		r.With(middlewares.ValidateChangePasswordPayload).
+			Put("/password", handlers.ChangePassword)
+		r.Post("/email/verify/resend", handlers.ResendEmailVerification)
+		r.Post("/2fa/setup", handlers.SetupTwoFactor)
+		r.With(middlewares.ValidateTwoFactorCodePayload).
+			Post("/2fa/enable", handlers.EnableTwoFactor)
+		r.With(middlewares.ValidateTwoFactorCodePayload).
+			Post("/2fa/disable", handlers.DisableTwoFactor)
+
 		r.Get("/credits", handlers.GetUserPointsAndCredits)
+		r.Get("/me", handlers.GetMe)
+		r.With(middlewares.ValidateProfilePayload).
+			Patch("/me", handlers.UpdateMe)
+		r.With(middlewares.ValidateDeleteAccountPayload).
+			Delete("/me", handlers.DeleteMe)
+		r.Get("/me/export", handlers.ExportMe)
+		r.With(middlewares.ParseIdFromURLParam).
+			Get("/users/{id}", handlers.GetUserProfile)
+
+		r.With(middlewares.RequireRole(utils.ROLE_MODERATOR, utils.ROLE_ADMIN)).
+			Get("/moderation-actions", handlers.GetModerationActions)
+		r.With(middlewares.RequireRole(utils.ROLE_ADMIN), middlewares.ParseIdFromURLParam, middlewares.ValidateRolePayload).
+			Put("/users/{id}/role", handlers.UpdateUserRole)
+		r.With(middlewares.RequireRole(utils.ROLE_ADMIN)).
+			Get("/admin/metrics", expvar.Handler().ServeHTTP)
+		r.With(middlewares.RequireRole(utils.ROLE_ADMIN)).
+			Get("/admin/audit-events", handlers.GetAuditEvents)
+
+		r.Get("/sessions", handlers.GetSessions)
+		r.With(middlewares.ParseIdFromURLParam).
+			Delete("/sessions/{id}", handlers.DeleteSession)
 
-		r.Get("/questions", handlers.GetQuestions)
+		r.Get("/api-keys", handlers.GetAPIKeys)
+		r.With(middlewares.ValidateAPIKeyPayload).
+			Post("/api-keys", handlers.CreateAPIKey)
 		r.With(middlewares.ParseIdFromURLParam).
+			Delete("/api-keys/{id}", handlers.DeleteAPIKey)
+	})
+
+	// Content routes (accept an access token or an API key with the required scope)
+	v1Router.Group(func(r chi.Router) {
+		r.Use(middlewares.VerifyAccessTokenOrAPIKey)
+
+		readQuestions := middlewares.RequireScope(utils.SCOPE_QUESTIONS_READ)
+		writeQuestions := middlewares.RequireScope(utils.SCOPE_QUESTIONS_WRITE)
+		readAnswers := middlewares.RequireScope(utils.SCOPE_ANSWERS_READ)
+		writeAnswers := middlewares.RequireScope(utils.SCOPE_ANSWERS_WRITE)
+		writeVotes := middlewares.RequireScope(utils.SCOPE_VOTES_WRITE)
+
+		r.With(readQuestions).
+			Get("/questions", handlers.GetQuestions)
+		r.With(readQuestions).
+			Get("/search", handlers.SearchQuestions)
+		r.With(readQuestions).
+			Get("/tags", handlers.GetTags)
+		r.With(readQuestions, middlewares.ParseIdFromURLParam).
 			Get("/question/{id}", handlers.GetQuestionById)
-		r.With(middlewares.ValidateQuestionPayload).
+		r.With(writeQuestions, middlewares.RequireVerifiedEmail, middlewares.ValidateQuestionPayload).
 			Post("/question", handlers.CreateQuestion)
-		r.With(middlewares.ParseIdFromURLParam, middlewares.ValidateQuestionPayload).
+		r.With(writeQuestions, middlewares.ParseIdFromURLParam, middlewares.ValidateQuestionPayload).
 			Put("/question/{id}", handlers.UpdateQuestion)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(writeQuestions, middlewares.ParseIdFromURLParam).
 			Delete("/question/{id}", handlers.DeleteQuestion)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(writeQuestions, middlewares.ParseIdFromURLParam).
 			Patch("/question/{id}", handlers.CloseQuestion)
+		r.With(writeQuestions, middlewares.ParseIdFromURLParam).
+			Post("/question/{id}/accept/{answerId}", handlers.AcceptAnswer)
+		r.With(readQuestions, middlewares.ParseIdFromURLParam).
+			Get("/question/{id}/revisions", handlers.GetQuestionRevisions)
+		r.With(writeQuestions, middlewares.ParseIdFromURLParam).
+			Post("/question/{id}/revisions/{revisionId}/rollback", handlers.RollbackQuestion)
 
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(readAnswers, middlewares.ParseIdFromURLParam).
 			Get("/question/{id}/answers", handlers.GetAnswersByQuestionId)
-		r.Get("/answers", handlers.GetAnswersByUserId)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(readAnswers).
+			Get("/answers", handlers.GetAnswersByUserId)
+		r.With(readAnswers, middlewares.ParseIdFromURLParam).
 			Get("/answer/{id}", handlers.GetAnswerById)
-		r.With(middlewares.ValidateAnswerPayload).
+		r.With(writeAnswers, middlewares.RequireVerifiedEmail, middlewares.ValidateAnswerPayload).
 			Post("/answer", handlers.CreateAnswer)
-		r.With(middlewares.ParseIdFromURLParam, middlewares.ValidateAnswerPayload).
+		r.With(writeAnswers, middlewares.ParseIdFromURLParam, middlewares.ValidateAnswerPayload).
 			Patch("/answer/{id}", handlers.UpdateAnswer)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(writeAnswers, middlewares.ParseIdFromURLParam).
 			Delete("/answer/{id}", handlers.DeleteAnswer)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(readAnswers, middlewares.ParseIdFromURLParam).
+			Get("/answer/{id}/revisions", handlers.GetAnswerRevisions)
+		r.With(writeAnswers, middlewares.ParseIdFromURLParam).
+			Post("/answer/{id}/revisions/{revisionId}/rollback", handlers.RollbackAnswer)
+		r.With(writeVotes, middlewares.RequireVerifiedEmail, middlewares.ParseIdFromURLParam).
 			Patch("/answer/{id}/upvote", handlers.VoteAnswer)
-		r.With(middlewares.ParseIdFromURLParam).
+		r.With(writeVotes, middlewares.RequireVerifiedEmail, middlewares.ParseIdFromURLParam).
 			Patch("/answer/{id}/downvote", handlers.VoteAnswer)
 	})
 
+	router.Get("/.well-known/jwks.json", handlers.GetJWKS)
 	router.Mount("/v1", v1Router)
 	return router
 }
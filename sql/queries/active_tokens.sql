@@ -2,8 +2,36 @@
 SELECT COUNT(*) FROM active_tokens
 WHERE sub = ? AND jti = ?;
 
+-- name: GetActiveTokenId :one
+SELECT id FROM active_tokens
+WHERE sub = ? AND jti = ?;
+
+-- name: GetActiveTokensBySub :many
+SELECT id, jti, device_name, user_agent, ip_address, created_at, last_seen_at FROM active_tokens
+WHERE sub = ?
+ORDER BY last_seen_at DESC;
+
+-- name: CreateActiveToken :execlastid
+INSERT INTO active_tokens (sub, jti, device_name, user_agent, ip_address, created_at, last_seen_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
 -- name: SetActiveToken :exec
-INSERT INTO active_tokens (sub, jti)
-VALUES (?, ?)
-ON DUPLICATE KEY UPDATE
-sub = VALUES(sub), jti = VALUES(jti);
\ No newline at end of file
+UPDATE active_tokens
+SET jti = ?, ip_address = ?, last_seen_at = ?
+WHERE id = ?;
+
+-- name: DeleteActiveToken :execrows
+DELETE FROM active_tokens
+WHERE id = ? AND sub = ?;
+
+-- name: DeleteActiveTokenByJti :exec
+DELETE FROM active_tokens
+WHERE sub = ? AND jti = ?;
+
+-- name: DeleteActiveTokensBySub :exec
+DELETE FROM active_tokens
+WHERE sub = ?;
+
+-- name: DeleteOtherActiveTokens :exec
+DELETE FROM active_tokens
+WHERE sub = ? AND id != ?;
\ No newline at end of file
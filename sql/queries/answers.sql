@@ -1,8 +1,9 @@
 -- name: GetAnswersByQuestionId :many
-SELECT answers.*, `name`, email FROM answers
+SELECT answers.*, `name`, email, questions.accepted_answer_id FROM answers
 INNER JOIN users ON users.id = answers.user_id
+INNER JOIN questions ON questions.id = answers.question_id
 WHERE question_id = ?
-ORDER BY votes DESC, answers.updated_at ASC;
+ORDER BY answers.id = questions.accepted_answer_id DESC, votes DESC, answers.updated_at ASC;
 
 -- name: GetAnswersByUserId :many
 SELECT * FROM answers
@@ -13,7 +14,23 @@ ORDER BY updated_at DESC;
 SELECT * FROM answers
 WHERE id = ?;
 
--- name: CreateAnswer :exec
+-- name: CheckIfAnswerExists :one
+SELECT COUNT(*) FROM answers
+WHERE id = ?
+FOR SHARE;
+
+-- name: CountUpvotedAnswersByQuestionId :one
+SELECT COUNT(*) FROM answers
+WHERE question_id = ? AND votes > 0
+FOR SHARE;
+
+-- name: GetTopAnswerByQuestionId :one
+SELECT * FROM answers
+WHERE question_id = ? AND user_id != ? AND votes > 0
+ORDER BY votes DESC, created_at ASC
+LIMIT 1;
+
+-- name: CreateAnswer :execlastid
 INSERT INTO answers (body, question_id, user_id, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?);
 
@@ -29,4 +46,12 @@ WHERE id = ? AND user_id = ?;
 -- name: UpdateAnswerVotes :exec
 UPDATE answers
 SET votes = votes + ?, updated_at = ?
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?;
+
+-- name: ReassignAnswers :exec
+UPDATE answers
+SET user_id = sqlc.arg(new_user_id)
+WHERE user_id = sqlc.arg(old_user_id);
+
+-- name: GetAllAnswerTexts :many
+SELECT question_id, body FROM answers;
\ No newline at end of file
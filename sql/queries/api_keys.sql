@@ -0,0 +1,25 @@
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = ?;
+
+-- name: GetApiKeysByUserId :many
+SELECT id, `name`, key_prefix, scopes, last_used_at, created_at FROM api_keys
+WHERE user_id = ?
+ORDER BY created_at DESC;
+
+-- name: CountApiKeysByUserId :one
+SELECT COUNT(*) FROM api_keys
+WHERE user_id = ?;
+
+-- name: CreateApiKey :exec
+INSERT INTO api_keys (user_id, `name`, key_prefix, key_hash, scopes, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = ?
+WHERE id = ?;
+
+-- name: DeleteApiKey :execrows
+DELETE FROM api_keys
+WHERE id = ? AND user_id = ?;
\ No newline at end of file
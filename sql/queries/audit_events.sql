@@ -0,0 +1,29 @@
+-- name: GetAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor_id) IS NULL OR actor_id = sqlc.narg(actor_id))
+  AND (sqlc.narg(action) IS NULL OR `action` = sqlc.narg(action))
+  AND (sqlc.narg(from_time) IS NULL OR created_at >= sqlc.narg(from_time))
+  AND (sqlc.narg(to_time) IS NULL OR created_at < sqlc.narg(to_time))
+  AND (sqlc.narg(cursor) IS NULL OR id < sqlc.narg(cursor))
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetAuditEventsByUserId :many
+SELECT * FROM audit_events
+WHERE actor_id = sqlc.arg(user_id)
+  OR (`action` = 'credits_change' AND JSON_EXTRACT(payload, '$.user_id') = sqlc.arg(user_id))
+ORDER BY id ASC;
+
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (actor_id, `action`, ip_address, user_agent, payload, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: PseudonymizeAuditEvents :exec
+UPDATE audit_events
+SET actor_id = sqlc.arg(new_actor_id), ip_address = '', user_agent = ''
+WHERE actor_id = sqlc.arg(old_actor_id);
+
+-- name: RemoveEmailFromAuditEvents :exec
+UPDATE audit_events
+SET payload = JSON_REMOVE(payload, '$.email')
+WHERE LOWER(JSON_UNQUOTE(JSON_EXTRACT(payload, '$.email'))) = sqlc.arg(email);
\ No newline at end of file
@@ -0,0 +1,40 @@
+-- name: GetOpenBountyByQuestionId :one
+SELECT * FROM bounties
+WHERE question_id = ? AND `status` = 'open'
+FOR UPDATE;
+
+-- name: GetOpenBountiesByUserId :many
+SELECT bounties.* FROM bounties
+INNER JOIN questions ON questions.id = bounties.question_id
+WHERE questions.user_id = ? AND bounties.`status` = 'open'
+FOR UPDATE;
+
+-- name: GetBountiesByUserId :many
+SELECT bounties.* FROM bounties
+INNER JOIN questions ON questions.id = bounties.question_id
+LEFT JOIN answers ON answers.id = bounties.awarded_answer_id
+WHERE questions.user_id = sqlc.arg(user_id) OR answers.user_id = sqlc.arg(user_id)
+ORDER BY bounties.id ASC;
+
+-- name: GetExpiredBounties :many
+SELECT bounties.* FROM bounties
+WHERE `status` = 'open' AND expires_at <= ?
+  AND NOT EXISTS (
+    SELECT 1 FROM answers
+    WHERE answers.question_id = bounties.question_id AND answers.created_at <= bounties.expires_at)
+ORDER BY expires_at ASC
+LIMIT ?;
+
+-- name: CreateBounty :exec
+INSERT INTO bounties (question_id, amount, expires_at, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: AddToBounty :exec
+UPDATE bounties
+SET amount = amount + ?, updated_at = ?
+WHERE id = ? AND `status` = 'open';
+
+-- name: SettleBounty :execrows
+UPDATE bounties
+SET `status` = ?, awarded_answer_id = ?, settled_at = ?, updated_at = ?
+WHERE id = ? AND `status` = 'open';
\ No newline at end of file
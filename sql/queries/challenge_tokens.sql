@@ -0,0 +1,7 @@
+-- name: UseChallengeToken :execrows
+INSERT IGNORE INTO used_challenge_tokens (jti, user_id, expires_at)
+VALUES (?, ?, ?);
+
+-- name: DeleteExpiredChallengeTokens :exec
+DELETE FROM used_challenge_tokens
+WHERE user_id = ? AND expires_at < ?;
\ No newline at end of file
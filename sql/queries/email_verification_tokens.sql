@@ -0,0 +1,17 @@
+-- name: GetEmailVerificationToken :one
+SELECT * FROM email_verification_tokens
+WHERE token_hash = ? AND used_at IS NULL AND expires_at > ?;
+
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (user_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?);
+
+-- name: UseEmailVerificationToken :execrows
+UPDATE email_verification_tokens
+SET used_at = ?
+WHERE id = ? AND used_at IS NULL;
+
+-- name: InvalidateEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = ?
+WHERE user_id = ? AND used_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- name: CreateLoginAttempt :exec
+INSERT IGNORE INTO login_attempts (attempt_key, failures, last_failed_at)
+VALUES (?, 0, ?);
+
+-- name: GetLoginAttemptForUpdate :one
+SELECT * FROM login_attempts
+WHERE attempt_key = ?
+FOR UPDATE;
+
+-- name: SetLoginAttempt :exec
+INSERT INTO login_attempts (attempt_key, failures, last_failed_at, locked_until)
+VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+  failures = VALUES(failures),
+  last_failed_at = VALUES(last_failed_at),
+  locked_until = VALUES(locked_until);
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE attempt_key = ?;
+
+-- name: DeleteStaleLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?);
\ No newline at end of file
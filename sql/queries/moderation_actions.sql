@@ -0,0 +1,19 @@
+-- name: GetModerationActions :many
+SELECT moderation_actions.*, `name` FROM moderation_actions
+INNER JOIN users ON users.id = moderation_actions.moderator_id
+ORDER BY moderation_actions.id DESC
+LIMIT ?;
+
+-- name: GetModerationActionsByTargetUserId :many
+SELECT id, `action`, target_type, target_id, created_at FROM moderation_actions
+WHERE target_user_id = ?
+ORDER BY id ASC;
+
+-- name: CreateModerationAction :exec
+INSERT INTO moderation_actions (moderator_id, `action`, target_type, target_id, target_user_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ReassignModerationActions :exec
+UPDATE moderation_actions
+SET moderator_id = sqlc.arg(new_user_id)
+WHERE moderator_id = sqlc.arg(old_user_id);
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: GetPasswordResetToken :one
+SELECT * FROM password_reset_tokens
+WHERE token_hash = ? AND used_at IS NULL AND expires_at > ?;
+
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+VALUES (?, ?, ?, ?);
+
+-- name: UsePasswordResetToken :execrows
+UPDATE password_reset_tokens
+SET used_at = ?
+WHERE id = ? AND used_at IS NULL;
+
+-- name: InvalidatePasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = ?
+WHERE user_id = ? AND used_at IS NULL;
\ No newline at end of file
@@ -1,19 +1,45 @@
 -- name: SearchQuestions :many
-SELECT id, title, body, `priority_level`, closed, updated_at FROM questions
-WHERE title LIKE ?
-ORDER BY closed ASC, `priority_level` DESC, responded_at ASC, updated_at DESC;
+SELECT id, title, body, `priority_level`, closed, responded_at, updated_at FROM questions
+WHERE title LIKE sqlc.arg(title)
+  AND (sqlc.arg(tag_count) = 0 OR id IN (
+    SELECT question_tags.question_id FROM question_tags
+    INNER JOIN tags ON tags.id = question_tags.tag_id
+    WHERE tags.`name` IN (sqlc.slice(tags))
+    GROUP BY question_tags.question_id
+    HAVING COUNT(*) = sqlc.arg(tag_count)))
+  AND (sqlc.narg(cursor_id) IS NULL
+    OR closed > sqlc.narg(cursor_closed)
+    OR (closed = sqlc.narg(cursor_closed) AND (`priority_level` < sqlc.narg(cursor_priority_level)
+      OR (`priority_level` = sqlc.narg(cursor_priority_level) AND (responded_at > sqlc.narg(cursor_responded_at)
+        OR (responded_at = sqlc.narg(cursor_responded_at) AND (updated_at < sqlc.narg(cursor_updated_at)
+          OR (updated_at = sqlc.narg(cursor_updated_at) AND id > sqlc.narg(cursor_id)))))))))
+ORDER BY closed ASC, `priority_level` DESC, responded_at ASC, updated_at DESC, id ASC
+LIMIT ?;
 
 -- name: GetQuestionsByUserId :many
-SELECT id, title, body, `priority_level`, closed, updated_at FROM questions
-WHERE user_id = ?
-ORDER BY closed ASC, `priority_level` DESC, responded_at ASC, updated_at DESC;
+SELECT id, title, body, `priority_level`, closed, responded_at, updated_at FROM questions
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.arg(tag_count) = 0 OR id IN (
+    SELECT question_tags.question_id FROM question_tags
+    INNER JOIN tags ON tags.id = question_tags.tag_id
+    WHERE tags.`name` IN (sqlc.slice(tags))
+    GROUP BY question_tags.question_id
+    HAVING COUNT(*) = sqlc.arg(tag_count)))
+  AND (sqlc.narg(cursor_id) IS NULL
+    OR closed > sqlc.narg(cursor_closed)
+    OR (closed = sqlc.narg(cursor_closed) AND (`priority_level` < sqlc.narg(cursor_priority_level)
+      OR (`priority_level` = sqlc.narg(cursor_priority_level) AND (responded_at > sqlc.narg(cursor_responded_at)
+        OR (responded_at = sqlc.narg(cursor_responded_at) AND (updated_at < sqlc.narg(cursor_updated_at)
+          OR (updated_at = sqlc.narg(cursor_updated_at) AND id > sqlc.narg(cursor_id)))))))))
+ORDER BY closed ASC, `priority_level` DESC, responded_at ASC, updated_at DESC, id ASC
+LIMIT ?;
 
 -- name: GetQuestionById :one
 SELECT questions.*, `name`, email FROM questions
 INNER JOIN users ON users.id = questions.user_id
 WHERE questions.id = ?;
 
--- name: CreateQuestion :exec
+-- name: CreateQuestion :execlastid
 INSERT INTO questions (title, body, `priority_level`, user_id, responded_at, created_at, updated_at)
 VALUES (?, ?, ?, ?, ?, ?, ?);
 
@@ -26,12 +52,58 @@ WHERE id = ? AND user_id = ? AND closed != 1;
 DELETE FROM questions
 WHERE id = ? AND user_id = ? AND closed != 1;
 
--- name: CloseQuestion :exec
+-- name: CloseQuestion :execrows
 UPDATE questions
 SET closed = 1, updated_at = ?
-WHERE id = ? AND user_id = ?;
+WHERE id = ? AND user_id = ? AND closed = 0;
+
+-- name: AcceptAnswer :execrows
+UPDATE questions
+SET accepted_answer_id = ?, updated_at = ?
+WHERE id = ? AND user_id = ? AND accepted_answer_id IS NULL AND closed = 0;
+
+-- name: GetAcceptedAnswerIdForUpdate :one
+SELECT accepted_answer_id FROM questions
+WHERE id = ?
+FOR UPDATE;
+
+-- name: ResetQuestionPriority :exec
+UPDATE questions
+SET `priority_level` = 0, updated_at = ?
+WHERE id = ?;
 
 -- name: RespondToQuestion :exec
 UPDATE questions
 SET responded_at = ?, updated_at = ?
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?;
+
+-- name: GetAllQuestionsByUserId :many
+SELECT * FROM questions
+WHERE user_id = ?
+ORDER BY created_at ASC;
+
+-- name: ReassignQuestions :exec
+UPDATE questions
+SET user_id = sqlc.arg(new_user_id)
+WHERE user_id = sqlc.arg(old_user_id);
+
+-- name: FullTextSearchQuestions :many
+SELECT questions.id, questions.title, questions.body,
+  CAST(
+    MATCH(questions.title) AGAINST (sqlc.arg(query)) * 3 + MATCH(questions.body) AGAINST (sqlc.arg(query)) +
+    COALESCE((SELECT SUM(MATCH(answers.body) AGAINST (sqlc.arg(query))) FROM answers
+      WHERE answers.question_id = questions.id), 0)
+  AS DOUBLE) AS score,
+  COALESCE((SELECT answers.body FROM answers
+    WHERE answers.question_id = questions.id AND MATCH(answers.body) AGAINST (sqlc.arg(query))
+    ORDER BY MATCH(answers.body) AGAINST (sqlc.arg(query)) DESC
+    LIMIT 1), '') AS answer_body
+FROM questions
+WHERE MATCH(questions.title) AGAINST (sqlc.arg(query))
+  OR MATCH(questions.body) AGAINST (sqlc.arg(query))
+  OR questions.id IN (SELECT answers.question_id FROM answers WHERE MATCH(answers.body) AGAINST (sqlc.arg(query)))
+ORDER BY score DESC, questions.id DESC
+LIMIT ?;
+
+-- name: GetAllQuestionTexts :many
+SELECT id, title, body FROM questions;
\ No newline at end of file
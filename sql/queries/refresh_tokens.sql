@@ -0,0 +1,12 @@
+-- name: GetRefreshToken :one
+SELECT * FROM refresh_tokens
+WHERE sub = ? AND jti = ?;
+
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (jti, parent_jti, family_id, sub, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: UseRefreshToken :execrows
+UPDATE refresh_tokens
+SET used_at = ?
+WHERE sub = ? AND jti = ? AND used_at IS NULL;
\ No newline at end of file
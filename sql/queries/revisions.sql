@@ -0,0 +1,29 @@
+-- name: GetRevisionsByQuestionId :many
+SELECT revisions.*, `name` FROM revisions
+INNER JOIN users ON users.id = revisions.editor_id
+WHERE question_id = ?
+ORDER BY revisions.id ASC;
+
+-- name: GetRevisionsByAnswerId :many
+SELECT revisions.*, `name` FROM revisions
+INNER JOIN users ON users.id = revisions.editor_id
+WHERE answer_id = ?
+ORDER BY revisions.id ASC;
+
+-- name: GetRevisionsByEditorId :many
+SELECT * FROM revisions
+WHERE editor_id = ?
+ORDER BY id ASC;
+
+-- name: GetRevisionById :one
+SELECT * FROM revisions
+WHERE id = ?;
+
+-- name: CreateRevision :exec
+INSERT INTO revisions (question_id, answer_id, title, body, editor_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ReassignRevisions :exec
+UPDATE revisions
+SET editor_id = sqlc.arg(new_user_id)
+WHERE editor_id = sqlc.arg(old_user_id);
\ No newline at end of file
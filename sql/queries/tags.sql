@@ -0,0 +1,29 @@
+-- name: GetTags :many
+SELECT `name`, COUNT(question_tags.question_id) AS question_count FROM tags
+INNER JOIN question_tags ON question_tags.tag_id = tags.id
+WHERE `name` LIKE ?
+GROUP BY tags.id
+ORDER BY question_count DESC, `name` ASC
+LIMIT ?;
+
+-- name: GetTagIdByName :one
+SELECT id FROM tags
+WHERE `name` = ?;
+
+-- name: GetTagsByQuestionIds :many
+SELECT question_tags.question_id, `name` FROM question_tags
+INNER JOIN tags ON tags.id = question_tags.tag_id
+WHERE question_tags.question_id IN (sqlc.slice(question_ids))
+ORDER BY `name` ASC;
+
+-- name: CreateTag :exec
+INSERT IGNORE INTO tags (`name`, created_at)
+VALUES (?, ?);
+
+-- name: AddQuestionTag :exec
+INSERT INTO question_tags (question_id, tag_id)
+VALUES (?, ?);
+
+-- name: DeleteQuestionTags :exec
+DELETE FROM question_tags
+WHERE question_id = ?;
\ No newline at end of file
@@ -10,6 +10,10 @@ VALUES (?, ?, ?, ?, ?);
 SELECT * FROM users
 WHERE email = ?;
 
+-- name: GetUserById :one
+SELECT * FROM users
+WHERE id = ?;
+
 -- name: GetUserPointsAndCredits :one
 SELECT points, credits FROM users
 WHERE id = ?;
@@ -27,4 +31,63 @@ WHERE id = ?;
 -- name: UpdateUserPoints :exec
 UPDATE users
 SET points = points + ?, updated_at = ?
+WHERE id = ?;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET `password` = ?, updated_at = ?
+WHERE id = ?;
+
+-- name: VerifyUserEmail :exec
+UPDATE users
+SET verified_at = ?, updated_at = ?
+WHERE id = ? AND verified_at IS NULL;
+
+-- name: SetUserTotpSecret :exec
+UPDATE users
+SET totp_secret = ?, updated_at = ?
+WHERE id = ? AND totp_enabled = 0;
+
+-- name: EnableUserTotp :exec
+UPDATE users
+SET totp_enabled = 1, updated_at = ?
+WHERE id = ?;
+
+-- name: UseUserTotpCounter :execrows
+UPDATE users
+SET totp_last_counter = ?
+WHERE id = ? AND totp_last_counter < ?;
+
+-- name: DisableUserTotp :exec
+UPDATE users
+SET totp_secret = NULL, totp_enabled = 0, updated_at = ?
+WHERE id = ?;
+
+-- name: GetUserRole :one
+SELECT `role` FROM users
+WHERE id = ?;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET `role` = ?, updated_at = ?
+WHERE id = ?;
+
+-- name: GetUserProfile :one
+SELECT id, `name`, bio, points, created_at,
+  (SELECT COUNT(*) FROM questions WHERE questions.user_id = users.id) AS question_count,
+  (SELECT COUNT(*) FROM answers WHERE answers.user_id = users.id) AS answer_count
+FROM users
+WHERE id = ?;
+
+-- name: UpdateUserProfile :exec
+UPDATE users
+SET `name` = ?, bio = ?, updated_at = ?
+WHERE id = ?;
+
+-- name: GetUserIdByEmail :one
+SELECT id FROM users
+WHERE email = ?;
+
+-- name: DeleteUser :exec
+DELETE FROM users
 WHERE id = ?;
\ No newline at end of file
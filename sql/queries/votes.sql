@@ -14,4 +14,14 @@ WHERE id = ?;
 -- name: Downvote :exec
 UPDATE votes
 SET val = -1, updated_at = ?
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?;
+
+-- name: GetVotesByUserId :many
+SELECT * FROM votes
+WHERE user_id = ?
+ORDER BY created_at ASC;
+
+-- name: ReassignVotes :exec
+UPDATE votes
+SET user_id = sqlc.arg(new_user_id)
+WHERE user_id = sqlc.arg(old_user_id);
\ No newline at end of file
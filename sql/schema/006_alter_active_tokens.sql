@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE active_tokens
+  DROP PRIMARY KEY,
+  ADD COLUMN id INT PRIMARY KEY AUTO_INCREMENT FIRST,
+  ADD COLUMN device_name VARCHAR(50) NOT NULL DEFAULT 'Unknown device',
+  ADD COLUMN user_agent VARCHAR(256) NOT NULL DEFAULT '',
+  ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '',
+  ADD COLUMN created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN last_seen_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- +goose Down
+-- Only the most recently used token of each user is kept, the newest one if several were used at the same time
+DELETE older FROM active_tokens older
+INNER JOIN active_tokens newer ON newer.sub = older.sub
+  AND (newer.last_seen_at > older.last_seen_at OR (newer.last_seen_at = older.last_seen_at AND newer.id > older.id));
+
+ALTER TABLE active_tokens
+  DROP COLUMN last_seen_at,
+  DROP COLUMN created_at,
+  DROP COLUMN ip_address,
+  DROP COLUMN user_agent,
+  DROP COLUMN device_name,
+  DROP COLUMN id,
+  ADD PRIMARY KEY (sub);
\ No newline at end of file
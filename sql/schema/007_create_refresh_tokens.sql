@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE refresh_tokens (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  jti VARCHAR(256) UNIQUE NOT NULL,
+  parent_jti VARCHAR(256),
+  family_id INT NOT NULL,
+  sub INT NOT NULL,
+  used_at DATETIME,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY(sub) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+INSERT INTO refresh_tokens (jti, family_id, sub, created_at)
+SELECT jti, id, sub, last_seen_at FROM active_tokens;
+
+-- +goose Down
+DROP TABLE refresh_tokens;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN verified_at DATETIME;
+
+-- Existing users registered before email verification was introduced,
+-- so they are not locked out when REQUIRE_EMAIL_VERIFICATION is turned on
+UPDATE users
+SET verified_at = created_at;
+
+CREATE TABLE email_verification_tokens (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  token_hash CHAR(64) UNIQUE NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE email_verification_tokens;
+
+ALTER TABLE users
+  DROP COLUMN verified_at;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN totp_secret VARCHAR(64),
+  ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT 0;
+
+CREATE TABLE recovery_codes (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  code_hash CHAR(64) NOT NULL,
+  used_at DATETIME,
+  created_at DATETIME NOT NULL,
+  UNIQUE(user_id, code_hash),
+  FOREIGN KEY(user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE recovery_codes;
+
+ALTER TABLE users
+  DROP COLUMN totp_enabled,
+  DROP COLUMN totp_secret;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE login_attempts (
+  attempt_key VARCHAR(128) PRIMARY KEY,
+  failures INT NOT NULL,
+  last_failed_at DATETIME NOT NULL,
+  locked_until DATETIME
+);
+
+-- +goose Down
+DROP TABLE login_attempts;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN `role` VARCHAR(10) NOT NULL DEFAULT 'user';
+
+CREATE TABLE moderation_actions (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  moderator_id INT NOT NULL,
+  `action` VARCHAR(20) NOT NULL,
+  target_type VARCHAR(10) NOT NULL,
+  target_id INT NOT NULL,
+  target_user_id INT NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY(moderator_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE moderation_actions;
+
+ALTER TABLE users
+  DROP COLUMN `role`;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE api_keys (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  user_id INT NOT NULL,
+  `name` VARCHAR(50) NOT NULL,
+  key_prefix CHAR(12) NOT NULL,
+  key_hash CHAR(64) UNIQUE NOT NULL,
+  scopes VARCHAR(256) NOT NULL,
+  last_used_at DATETIME,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE api_keys;
\ No newline at end of file
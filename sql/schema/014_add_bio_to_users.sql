@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN bio VARCHAR(160) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE users
+  DROP COLUMN bio;
\ No newline at end of file
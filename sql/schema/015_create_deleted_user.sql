@@ -0,0 +1,9 @@
+-- +goose Up
+-- Placeholder that takes over the content of deleted accounts.
+-- It has no usable password, so nobody can log in as this user.
+INSERT INTO users (`name`, email, `password`, verified_at, created_at, updated_at)
+VALUES ('Deleted User', 'deleted-user@invalid', '', NOW(), NOW(), NOW());
+
+-- +goose Down
+DELETE FROM users
+WHERE email = 'deleted-user@invalid';
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE audit_events (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  actor_id INT,
+  `action` VARCHAR(30) NOT NULL,
+  ip_address VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(256) NOT NULL,
+  payload JSON NOT NULL,
+  created_at DATETIME NOT NULL,
+  INDEX(actor_id, created_at),
+  INDEX(`action`, created_at),
+  INDEX(created_at)
+);
+
+-- +goose Down
+DROP TABLE audit_events;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE INDEX questions_listing_idx
+  ON questions (closed, `priority_level` DESC, responded_at, updated_at DESC, id);
+
+CREATE INDEX questions_user_listing_idx
+  ON questions (user_id, closed, `priority_level` DESC, responded_at, updated_at DESC, id);
+
+-- +goose Down
+DROP INDEX questions_user_listing_idx ON questions;
+
+DROP INDEX questions_listing_idx ON questions;
\ No newline at end of file
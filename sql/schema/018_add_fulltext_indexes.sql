@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE FULLTEXT INDEX questions_title_ft ON questions (title);
+
+CREATE FULLTEXT INDEX questions_body_ft ON questions (body);
+
+CREATE FULLTEXT INDEX answers_body_ft ON answers (body);
+
+-- +goose Down
+DROP INDEX answers_body_ft ON answers;
+
+DROP INDEX questions_body_ft ON questions;
+
+DROP INDEX questions_title_ft ON questions;
\ No newline at end of file
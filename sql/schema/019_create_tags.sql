@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE tags (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  `name` VARCHAR(30) UNIQUE NOT NULL,
+  created_at DATETIME NOT NULL
+);
+
+CREATE TABLE question_tags (
+  question_id INT NOT NULL,
+  tag_id INT NOT NULL,
+  PRIMARY KEY(question_id, tag_id),
+  INDEX(tag_id),
+  FOREIGN KEY(question_id) REFERENCES questions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY(tag_id) REFERENCES tags(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE question_tags;
+
+DROP TABLE tags;
\ No newline at end of file
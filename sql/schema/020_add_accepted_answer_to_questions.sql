@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE questions
+ADD COLUMN accepted_answer_id INT NULL;
+
+-- +goose Down
+ALTER TABLE questions
+DROP COLUMN accepted_answer_id;
\ No newline at end of file
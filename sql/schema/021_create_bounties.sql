@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE bounties (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  question_id INT NOT NULL,
+  amount INT NOT NULL,
+  `status` VARCHAR(10) NOT NULL DEFAULT 'open',
+  awarded_answer_id INT,
+  expires_at DATETIME NOT NULL,
+  settled_at DATETIME,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX(question_id, `status`),
+  INDEX(`status`, expires_at),
+  FOREIGN KEY(question_id) REFERENCES questions(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- The priority credits of the open questions are put in escrow as well.
+-- Migrations cannot read BOUNTY_DURATION_DAYS, so these bounties always run
+-- for the default 7 days, whatever the server is configured with.
+INSERT INTO bounties (question_id, amount, `status`, expires_at, created_at, updated_at)
+SELECT id, `priority_level`, 'open', NOW() + INTERVAL 7 DAY, NOW(), NOW() FROM questions
+WHERE closed = 0 AND `priority_level` > 0;
+
+-- +goose Down
+DROP TABLE bounties;
\ No newline at end of file
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE revisions (
+  id INT PRIMARY KEY AUTO_INCREMENT,
+  question_id INT,
+  answer_id INT,
+  title VARCHAR(50),
+  body VARCHAR(300) NOT NULL,
+  editor_id INT NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY(question_id) REFERENCES questions(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY(answer_id) REFERENCES answers(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY(editor_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- The current text of the existing questions and answers is their first revision
+INSERT INTO revisions (question_id, title, body, editor_id, created_at)
+SELECT id, title, body, user_id, updated_at FROM questions;
+
+INSERT INTO revisions (answer_id, body, editor_id, created_at)
+SELECT id, body, user_id, updated_at FROM answers;
+
+-- +goose Down
+DROP TABLE revisions;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE users
+  ADD COLUMN totp_last_counter BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE users
+  DROP COLUMN totp_last_counter;
\ No newline at end of file
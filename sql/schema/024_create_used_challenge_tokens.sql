@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE used_challenge_tokens (
+  jti VARCHAR(256) PRIMARY KEY,
+  user_id INT NOT NULL,
+  expires_at DATETIME NOT NULL,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE used_challenge_tokens;
\ No newline at end of file